@@ -0,0 +1,106 @@
+package backend
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+)
+
+// NewAPIHandler serves the captured reels as JSON for --serve:
+//
+//	GET  /reels                   every captured reel, in feed order
+//	GET  /reels/{index}           one reel (1-based)
+//	GET  /reels/{index}/comments  the comments captured for a reel so far
+//	POST /navigate/{index}        move to a reel
+//
+// Reads go straight to b. Navigation is handed to navigate rather than
+// calling SyncTo here, so the player follows the browser instead of drifting
+// out of sync with it. navigate reports whether the TUI took the move; it
+// turns it down while a reel is loading or the feed isn't showing, which
+// answers 409.
+func NewAPIHandler(b Backend, navigate func(index int) bool) http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /reels", func(w http.ResponseWriter, r *http.Request) {
+		reels := []*ReelInfo{}
+		for i := 1; i <= b.GetTotal(); i++ {
+			if info, err := b.GetReel(i); err == nil {
+				reels = append(reels, info)
+			}
+		}
+		writeJSON(w, http.StatusOK, reels)
+	})
+
+	mux.HandleFunc("GET /reels/{index}", func(w http.ResponseWriter, r *http.Request) {
+		index, ok := apiIndex(w, r, b)
+		if !ok {
+			return
+		}
+		info, err := b.GetReel(index)
+		if err != nil {
+			writeAPIError(w, http.StatusNotFound, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, info)
+	})
+
+	mux.HandleFunc("GET /reels/{index}/comments", func(w http.ResponseWriter, r *http.Request) {
+		index, ok := apiIndex(w, r, b)
+		if !ok {
+			return
+		}
+		comments, err := b.GetComments(index)
+		if err != nil {
+			writeAPIError(w, http.StatusNotFound, err)
+			return
+		}
+		if comments == nil {
+			comments = []Comment{}
+		}
+		writeJSON(w, http.StatusOK, comments)
+	})
+
+	mux.HandleFunc("POST /navigate/{index}", func(w http.ResponseWriter, r *http.Request) {
+		index, ok := apiIndex(w, r, b)
+		if !ok {
+			return
+		}
+		if !navigate(index) {
+			writeAPIError(w, http.StatusConflict, fmt.Errorf("navigation to %d ignored: a reel is loading or the feed isn't showing", index))
+			return
+		}
+		// SyncTo runs in the background, so the move isn't done yet
+		writeJSON(w, http.StatusAccepted, map[string]int{"index": index})
+	})
+
+	return mux
+}
+
+// apiIndex parses the {index} path value, writing a 400 if it isn't a number
+// or a 404 if no reel has been captured there.
+func apiIndex(w http.ResponseWriter, r *http.Request, b Backend) (int, bool) {
+	index, err := strconv.Atoi(r.PathValue("index"))
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, fmt.Errorf("invalid index %q", r.PathValue("index")))
+		return 0, false
+	}
+	if total := b.GetTotal(); index < 1 || index > total {
+		writeAPIError(w, http.StatusNotFound, fmt.Errorf("index %d out of range (1-%d)", index, total))
+		return 0, false
+	}
+	return index, true
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Printf("api: write response: %v", err)
+	}
+}
+
+func writeAPIError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
@@ -3,10 +3,12 @@ package backend
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"math/rand"
 	"os"
+	"regexp"
 	"slices"
 	"time"
 
@@ -24,15 +26,22 @@ func NewChromeBackend(userDataDir, cacheDir, configDir string) *ChromeBackend {
 		userDataDir: userDataDir,
 		cacheDir:    cacheDir,
 		configDir:   configDir,
+		fetchBody:   fetchResponseBody,
+		stopping:    make(chan struct{}),
+		watchDone:   make(chan struct{}),
 	}
 
-	b.initStorage()
+	b.storageErr = b.initStorage()
 
 	return &b
 }
 
 // Start initializes Chrome and navigates to Instagram homepage
 func (b *ChromeBackend) Start(headless bool) error {
+	if b.storageErr != nil {
+		return b.storageErr
+	}
+
 	// Create user data directory for persistent sessions
 	err := os.MkdirAll(b.userDataDir, 0755)
 	if err != nil {
@@ -66,7 +75,7 @@ func (b *ChromeBackend) Start(headless bool) error {
 	b.feedCtx = feedCtx
 	b.feedCancel = feedCancel
 	b.ctx = feedCtx
-	b.feed = NewFeedCursor(feedCtx)
+	b.feed = NewFeedCursor(feedCtx, b.events)
 	b.active = b.feed
 
 	chromedp.ListenTarget(feedCtx, func(ev interface{}) {
@@ -84,15 +93,106 @@ func (b *ChromeBackend) Start(headless bool) error {
 			},
 		}),
 		chromedp.Navigate("https://www.instagram.com/"),
-		chromedp.Sleep(2*time.Second), // sleep to let page load
+		waitReady(homeReadyJS),
 	)
 	if err != nil {
 		return fmt.Errorf("failed to start: %w", err)
 	}
 
+	b.watching.Store(true)
+	go b.watchBrowser(feedCtx)
+
+	return nil
+}
+
+const (
+	// homeReadyJS is true once the home page shows either the login form or
+	// the logged-in navigation
+	homeReadyJS = `document.querySelector('input[name="username"], input[name="email"], a[href="/explore/"]') !== null`
+	// errorPageJS is true when instagram served its "Something went wrong"
+	// interstitial (rate limiting, maintenance) instead of the page
+	errorPageJS = `(!document.querySelector('video[playsinline]') && /something went wrong/i.test(document.body ? document.body.innerText : ''))`
+	// feedReadyJS is true once the reels feed has rendered a video, or the
+	// error page has rendered in its place
+	feedReadyJS = `(document.querySelector('video[playsinline]') !== null || ` + errorPageJS + `)`
+)
+
+// ErrErrorPage is returned when instagram keeps serving its "Something went
+// wrong" page instead of the reels feed.
+var ErrErrorPage = errors.New(`instagram is showing "Something went wrong" (rate limited or down for maintenance)`)
+
+// ErrBrowserDisconnected is sent with EventError when Chrome exits (killed,
+// out of memory, crashed) while the backend is still running.
+var ErrBrowserDisconnected = errors.New("browser disconnected: chrome exited or crashed")
+
+// watchBrowser waits for ctx to end. chromedp cancels it when the connection
+// to Chrome is lost, so unless Stop did that, Chrome died under us and every
+// later chromedp.Run would fail; EventError tells the TUI to stop there.
+func (b *ChromeBackend) watchBrowser(ctx context.Context) {
+	defer close(b.watchDone)
+
+	select {
+	case <-ctx.Done():
+	case <-b.stopping:
+		return
+	}
+	select {
+	case <-b.stopping:
+		return
+	default:
+	}
+
+	log.Printf("browser: lost connection to chrome: %v", context.Cause(ctx))
+	select {
+	case b.events <- Event{Type: EventError, Err: ErrBrowserDisconnected}:
+	case <-b.stopping:
+	}
+}
+
+// onErrorPage reports whether the feed window is showing the error page.
+func (b *ChromeBackend) onErrorPage() bool {
+	var errorPage bool
+	if err := chromedp.Run(b.feedCtx, chromedp.Evaluate(errorPageJS, &errorPage)); err != nil {
+		return false
+	}
+	return errorPage
+}
+
+// recoverErrorPage reloads the feed once if it's showing the error page, as
+// its own reload button would. Returns ErrErrorPage if it's still there.
+func (b *ChromeBackend) recoverErrorPage() error {
+	if !b.onErrorPage() {
+		return nil
+	}
+	log.Printf("instagram error page, reloading")
+	if err := chromedp.Run(b.feedCtx, chromedp.Reload(), waitReady(feedReadyJS)); err != nil {
+		return fmt.Errorf("failed to reload error page: %w", err)
+	}
+	if b.onErrorPage() {
+		return ErrErrorPage
+	}
 	return nil
 }
 
+// waitReady polls the page until readyJS is true, for at most
+// page_load_timeout_ms (0 = no limit). A page that never gets there is logged
+// and carried on with, like the fixed sleep this replaced.
+func waitReady(readyJS string) chromedp.Action {
+	return chromedp.ActionFunc(func(ctx context.Context) error {
+		timeout := time.Duration(max(GetSettings().PageLoadTimeoutMs, 0)) * time.Millisecond
+		var ready bool
+		err := chromedp.Poll(readyJS, &ready,
+			chromedp.WithPollingInterval(100*time.Millisecond),
+			chromedp.WithPollingTimeout(timeout),
+		).Do(ctx)
+		if errors.Is(err, chromedp.ErrPollingTimeout) {
+			log.Printf("page not ready after %v, continuing", timeout)
+			return nil
+		}
+		return err
+	})
+}
+
 // NeedsLogin checks if login is required by looking for login form elements
 func (b *ChromeBackend) NeedsLogin() (bool, error) {
 	var needsLogin bool
@@ -106,25 +206,110 @@ func (b *ChromeBackend) NeedsLogin() (bool, error) {
 	return needsLogin, err
 }
 
-// NavigateToReels goes to /reels and syncs to first captured reel
+var (
+	// reelURLRegex extracts the shortcode from /reel/, /reels/ and /p/ links
+	reelURLRegex = regexp.MustCompile(`(?:^|/)(?:reels?|p)/([A-Za-z0-9_-]+)`)
+	// shortcodeRegex matches a bare shortcode
+	shortcodeRegex = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+)
+
+// ParseReelCode extracts a reel shortcode from a reel URL
+// (https://www.instagram.com/reel/ABC123/) or returns arg if it already is one.
+func ParseReelCode(arg string) (string, error) {
+	if m := reelURLRegex.FindStringSubmatch(arg); m != nil {
+		return m[1], nil
+	}
+	if shortcodeRegex.MatchString(arg) {
+		return arg, nil
+	}
+	return "", fmt.Errorf("not a reel URL or shortcode: %q", arg)
+}
+
+// SetStartReel makes NavigateToReels open the feed at the reel with the given
+// shortcode instead of the generic feed. Must be called before NavigateToReels.
+func (b *ChromeBackend) SetStartReel(code string) {
+	b.startCode = code
+}
+
+// SetDumpMode puts the backend in --dump mode: it sends EventCaptureComplete
+// once count reels are captured and skips the DM session and comment GIF
+// downloads, which a metadata dump never shows.
+func (b *ChromeBackend) SetDumpMode(count int) {
+	b.dumpTarget = count
+}
+
+// waitForCapture polls until the reel with the given shortcode has been
+// captured from a GraphQL response, or timeout elapses.
+func (b *ChromeBackend) waitForCapture(code string, timeout time.Duration) bool {
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		b.reelsMu.RLock()
+		for _, r := range b.reels {
+			if r.Code == code {
+				b.reelsMu.RUnlock()
+				return true
+			}
+		}
+		b.reelsMu.RUnlock()
+		time.Sleep(250 * time.Millisecond)
+	}
+	return false
+}
+
+// NavigateToReels goes to /reels and syncs to first captured reel. With a
+// start reel set, it opens /reels/<code>/ so that reel plays first and the
+// feed continues from it.
 func (b *ChromeBackend) NavigateToReels() error {
+	target := "https://www.instagram.com/reels/"
+	if b.startCode != "" {
+		target += b.startCode + "/"
+	}
 	if err := chromedp.Run(b.feedCtx,
-		chromedp.Navigate("https://www.instagram.com/reels/"),
-		chromedp.Sleep(2*time.Second),
+		chromedp.Navigate(target),
+		waitReady(feedReadyJS),
 	); err != nil {
 		return fmt.Errorf("failed to navigate to reels: %w", err)
 	}
+	if err := b.recoverErrorPage(); err != nil {
+		return err
+	}
+
+	// Don't scroll past the shared reel before its clip response lands
+	if b.startCode != "" && !b.waitForCapture(b.startCode, 10*time.Second) {
+		log.Printf("start reel %s was not captured, continuing with the feed", b.startCode)
+		b.events <- Event{Type: EventStartReelMissed}
+	}
 
 	// initial sync
-	for i := 0; i < MaxRetries; i++ {
+	retries, timeout := syncLimits()
+	var deadline time.Time
+	if timeout > 0 {
+		deadline = time.Now().Add(timeout)
+	}
+	for i := 0; i < retries; i++ {
+		if !deadline.IsZero() && time.Now().After(deadline) {
+			return fmt.Errorf("initial sync timed out after %d scrolls", i)
+		}
+		if i > 0 {
+			emitSyncProgress(b.events, i+1, retries)
+		}
 		info, err := b.GetCurrent()
 		if err == nil && info != nil {
+			// the first reel plays without a SyncTo, which pins the rest
+			b.pinVideo(info.Index)
 			b.events <- Event{Type: EventSyncComplete}
+			if b.dumpTarget > 0 {
+				return nil
+			}
 			if err := b.startDMSession(); err != nil {
 				log.Printf("dm session: %v", err)
 			}
 			return nil
 		}
+		// the error page can also replace the feed mid-sync
+		if err := b.recoverErrorPage(); err != nil {
+			return err
+		}
 		if err := b.feed.scrollDown(); err != nil {
 			return err
 		}
@@ -133,8 +318,56 @@ func (b *ChromeBackend) NavigateToReels() error {
 	return fmt.Errorf("could not complete initial sync")
 }
 
+// ReloadFeed navigates the feed window back to /reels, e.g. after the session
+// expired and the user logged back in.
+func (b *ChromeBackend) ReloadFeed() error {
+	if err := chromedp.Run(b.feedCtx,
+		chromedp.Navigate("https://www.instagram.com/reels/"),
+		waitReady(feedReadyJS),
+	); err != nil {
+		return fmt.Errorf("failed to reload reels: %w", err)
+	}
+	if err := b.recoverErrorPage(); err != nil {
+		return err
+	}
+	b.loginRequired.Store(false)
+	return nil
+}
+
+// notifyLoginRequired emits EventLoginRequired the first time a logged-out
+// response is seen.
+func (b *ChromeBackend) notifyLoginRequired() {
+	if b.loginRequired.CompareAndSwap(false, true) {
+		b.events <- Event{Type: EventLoginRequired}
+	}
+}
+
+// syncLimits returns the configured scroll attempt limit and overall timeout
+// for a sync. A zero timeout means no limit.
+func syncLimits() (int, time.Duration) {
+	s := GetSettings()
+	retries := s.SyncMaxRetries
+	if retries < 1 {
+		retries = MaxRetries
+	}
+	return retries, time.Duration(max(s.SyncTimeoutMs, 0)) * time.Millisecond
+}
+
+// emitSyncProgress reports the current attempt of a long sync. Progress is
+// best-effort, so it's dropped rather than blocking when the channel is full.
+func emitSyncProgress(events chan<- Event, attempt, total int) {
+	select {
+	case events <- Event{Type: EventSyncProgress, Count: attempt, Total: total}:
+	default:
+	}
+}
+
 // Stop closes the browser
 func (b *ChromeBackend) Stop() {
+	close(b.stopping)
+	if b.watching.Load() {
+		<-b.watchDone
+	}
 	b.stopDMSession()
 	if b.feedCancel != nil {
 		b.feedCancel()
@@ -142,6 +375,9 @@ func (b *ChromeBackend) Stop() {
 	if b.allocCancel != nil {
 		b.allocCancel()
 	}
+	if gifCache != nil {
+		b.sweepGifs()
+	}
 	close(b.events)
 }
 
@@ -214,6 +450,38 @@ func (b *ChromeBackend) GetReel(index int) (*ReelInfo, error) {
 	return &ReelInfo{Index: index, Total: total, Reel: reel}, nil
 }
 
+// PeekReel returns reel info offset positions from the current reel, without
+// scrolling. "Current" is the browser's position, same as GetCurrent.
+func (b *ChromeBackend) PeekReel(offset int) (*ReelInfo, error) {
+	idx, _, err := b.activeCursor().Current()
+	if err != nil {
+		return nil, err
+	}
+	return b.GetReel(idx + offset)
+}
+
+// GetComments returns a copy of the comments captured so far for the reel at
+// index (1-based). Replies are spliced into the cached slice in place, so the
+// copy is taken under reelsMu.
+func (b *ChromeBackend) GetComments(index int) ([]Comment, error) {
+	cur := b.activeCursor()
+	total := cur.Total()
+	if index < 1 || index > total {
+		return nil, fmt.Errorf("index %d out of range (1-%d)", index, total)
+	}
+	pk := cur.PKAt(index)
+	if pk == "" {
+		return nil, fmt.Errorf("no pk at index %d", index)
+	}
+	b.reelsMu.RLock()
+	defer b.reelsMu.RUnlock()
+	r, ok := b.reels[pk]
+	if !ok {
+		return nil, fmt.Errorf("reel pk=%s not in cache", pk)
+	}
+	return slices.Clone(r.Comments), nil
+}
+
 // updateReelComments appends comments to a reel by PK, or sets them if none exist yet.
 func (b *ChromeBackend) updateReelComments(pk string, comments []Comment) {
 	b.mutateReelByPK(pk, func(r *Reel) {
@@ -260,6 +528,7 @@ func (b *ChromeBackend) GetTotal() int {
 // SyncTo navigates the active cursor to the given index. Comments are cleared
 // up-front because arrow-key scrolls don't trigger Instagram's auto-close.
 func (b *ChromeBackend) SyncTo(index int) error {
+	b.pinVideo(index)
 	b.ClearComments()
 	return b.activeCursor().SyncTo(index)
 }
@@ -338,6 +607,30 @@ func (b *ChromeBackend) ToggleLike() (bool, error) {
 	return true, nil
 }
 
+// RefreshCurrent re-fetches the current reel's counts and liked/saved state
+func (b *ChromeBackend) RefreshCurrent() (*ReelInfo, error) {
+	_, pk, err := b.activeCursor().Current()
+	if err != nil {
+		return nil, err
+	}
+
+	media, err := fetchMediaInfo(b.ctx, pk)
+	if err != nil {
+		return nil, err
+	}
+
+	if !b.mutateReelByPK(pk, func(r *Reel) {
+		r.LikeCount = media.LikeCount
+		r.CommentCount = media.CommentCount
+		r.RepostCount = media.MediaRepostCount
+		r.Liked = media.HasLiked
+		r.Saved = media.HasViewerSaved
+	}) {
+		return nil, fmt.Errorf("reel pk=%s not in cache", pk)
+	}
+	return b.GetCurrent()
+}
+
 // ToggleRepost clicks the repost button for the current reel
 func (b *ChromeBackend) ToggleRepost() (bool, error) {
 	if b.IsSyncing() {
@@ -54,24 +54,13 @@ func chromeBinaryName(platform string) string {
 //  2. A system-installed Chrome/Chromium found via PATH or well-known locations
 //  3. Auto-download Chrome for Testing if no binary was found in either locations (linux64, mac-arm64)
 func EnsureChromium(userDataDir string) (string, error) {
-	chromiumDir := filepath.Join(filepath.Dir(userDataDir), "chromium")
-
-	platform, err := platformString()
-
-	// Check for our managed download
-	if err == nil {
-		path := filepath.Join(chromiumDir, chromeBinaryName(platform))
-		if _, err := os.Stat(path); err == nil {
-			return path, nil
-		}
-	}
-
-	// Check for system Chrome
-	if path := findSystemChrome(); path != "" {
+	if path := FindChromium(userDataDir); path != "" {
 		return path, nil
 	}
 
 	// Download Chrome for Testing (only if platform is supported)
+	chromiumDir := filepath.Join(filepath.Dir(userDataDir), "chromium")
+	platform, err := platformString()
 	if err != nil {
 		return "", fmt.Errorf(
 			"no Chrome/Chromium found and auto-download is not available for %s/%s\n"+
@@ -86,6 +75,18 @@ func EnsureChromium(userDataDir string) (string, error) {
 	return filepath.Join(chromiumDir, chromeBinaryName(platform)), nil
 }
 
+// FindChromium is EnsureChromium without the download: our managed Chrome or a
+// system one, or "" if neither is installed.
+func FindChromium(userDataDir string) string {
+	if platform, err := platformString(); err == nil {
+		path := filepath.Join(filepath.Dir(userDataDir), "chromium", chromeBinaryName(platform))
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
+	return findSystemChrome()
+}
+
 // findSystemChrome looks for an existing Chrome/Chromium installation.
 func findSystemChrome() string {
 	var locations []string
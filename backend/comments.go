@@ -2,6 +2,7 @@ package backend
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/url"
 	"strings"
 
@@ -88,7 +89,7 @@ func (b *ChromeBackend) extractComments(edges []commentEdge, parentCommentID str
 			gifURLs = append(gifURLs, c.GifUrl)
 		}
 	}
-	if len(gifURLs) == 0 {
+	if len(gifURLs) == 0 || b.dumpTarget > 0 {
 		return comments
 	}
 
@@ -303,3 +304,51 @@ func (b *ChromeBackend) FetchChildComments(parentPK string) {
 		b.insertChildComments(reelPK, parentPK, children)
 	}
 }
+
+// ToggleCommentLike likes or unlikes a comment on the current reel and
+// returns its new state. Comment elements in the DOM carry no PK to find
+// the right heart by, so this calls the web endpoint the heart itself posts
+// to. The cached comment is flipped first and flipped back if that fails.
+func (b *ChromeBackend) ToggleCommentLike(commentPK string) (bool, error) {
+	_, pk, err := b.activeCursor().Current()
+	if err != nil {
+		return false, err
+	}
+
+	liked, found := false, false
+	flip := func(r *Reel) {
+		for i := range r.Comments {
+			c := &r.Comments[i]
+			if c.PK != commentPK {
+				continue
+			}
+			c.HasLikedComment = !c.HasLikedComment
+			if c.HasLikedComment {
+				c.CommentLikeCount++
+			} else {
+				c.CommentLikeCount = max(c.CommentLikeCount-1, 0)
+			}
+			liked, found = c.HasLikedComment, true
+			return
+		}
+	}
+	b.mutateReelByPK(pk, flip)
+	if !found {
+		return false, fmt.Errorf("comment pk=%s not in cache", commentPK)
+	}
+
+	action := "unlike"
+	if liked {
+		action = "like"
+	}
+	endpoint := "https://www.instagram.com/api/v1/web/comments/" + action + "/" + url.PathEscape(commentPK) + "/"
+	result, err := postWebAPI(b.ctx, endpoint)
+	if err == nil && !strings.Contains(result, `"status":"ok"`) {
+		err = fmt.Errorf("%s comment: unexpected response", action)
+	}
+	if err != nil {
+		b.mutateReelByPK(pk, flip)
+		return !liked, err
+	}
+	return liked, nil
+}
@@ -339,6 +339,7 @@ func (b *ChromeBackend) EnterChatMode(threadKey string) error {
 	b.ctx = b.dmCtx
 	b.modeMu.Unlock()
 
+	b.pinVideo(1)
 	go cc.SyncTo(1)
 	return nil
 }
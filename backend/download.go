@@ -0,0 +1,173 @@
+package backend
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/chromedp/cdproto/cdp"
+	cdpio "github.com/chromedp/cdproto/io"
+	"github.com/chromedp/cdproto/network"
+	"github.com/chromedp/cdproto/page"
+	"github.com/chromedp/chromedp"
+)
+
+// instagramReferer is sent with header-carrying downloads. Some CDN hosts
+// 403 a video request that doesn't look like it came from instagram.com.
+const instagramReferer = "https://www.instagram.com/"
+
+// downloadSem caps the video/pfp/gif fetches in flight at once
+// (max_concurrent_downloads), so deep prefetch plus a long comment thread's
+// gifs don't pile up on the network or the page
+var (
+	downloadSem     chan struct{}
+	downloadSemOnce sync.Once
+)
+
+// acquireDownload blocks until a download slot is free and returns the func
+// that releases it.
+func acquireDownload() func() {
+	downloadSemOnce.Do(func() {
+		downloadSem = make(chan struct{}, max(GetSettings().MaxDownloads, 1))
+	})
+	downloadSem <- struct{}{}
+	return func() { <-downloadSem }
+}
+
+// fetchVideo downloads a reel's video using the configured download_strategy.
+// "auto" tries plain HTTP, then HTTP with browser headers, then the page's
+// own network stack, logging whichever one worked.
+func (b *ChromeBackend) fetchVideo(url string) ([]byte, error) {
+	strategies := []string{GetSettings().DownloadStrategy}
+	if strategies[0] == "auto" {
+		strategies = []string{"http", "headers", "cdp"}
+	}
+
+	var lastErr error
+	for _, s := range strategies {
+		start := time.Now()
+		var data []byte
+		var err error
+		switch s {
+		case "http":
+			data, err = fetchHTTP(url, nil)
+		case "headers":
+			data, err = fetchHTTP(url, b.browserHeaders())
+		case "cdp":
+			data, err = b.fetchCDP(url)
+		default:
+			err = fmt.Errorf("unknown download strategy %q", s)
+		}
+		if err == nil {
+			if len(strategies) > 1 && s != strategies[0] {
+				log.Printf("download: %s failed, fetched via %s", strategies[0], s)
+			}
+			log.Printf("download: %d KB via %s in %v", len(data)/1024, s, time.Since(start).Round(time.Millisecond))
+			return data, nil
+		}
+		lastErr = fmt.Errorf("%s: %w", s, err)
+	}
+	log.Printf("download: all strategies failed: %v", lastErr)
+	return nil, lastErr
+}
+
+// fetchHTTP fetches a single URL over plain Go HTTP with optional headers.
+func fetchHTTP(url string, headers map[string]string) ([]byte, error) {
+	defer acquireDownload()()
+
+	client := &http.Client{Timeout: 30 * time.Second}
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("status %d", resp.StatusCode)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// browserHeaders returns the Referer/Origin/User-Agent the feed page would
+// send. The user agent is read from the browser once and reused.
+func (b *ChromeBackend) browserHeaders() map[string]string {
+	b.uaOnce.Do(func() {
+		var ua string
+		if err := chromedp.Run(b.feedCtx, chromedp.Evaluate(`navigator.userAgent`, &ua)); err == nil {
+			b.userAgent = ua
+		}
+	})
+
+	headers := map[string]string{
+		"Referer": instagramReferer,
+		"Origin":  "https://www.instagram.com",
+	}
+	if b.userAgent != "" {
+		headers["User-Agent"] = b.userAgent
+	}
+	return headers
+}
+
+// fetchCDP downloads a URL through Network.loadNetworkResource, which issues
+// the request from the feed page's frame so it carries the page's cookies,
+// referer and user agent without being subject to CORS.
+func (b *ChromeBackend) fetchCDP(url string) ([]byte, error) {
+	defer acquireDownload()()
+
+	var data []byte
+	err := chromedp.Run(b.feedCtx, chromedp.ActionFunc(func(ctx context.Context) error {
+		tree, err := page.GetFrameTree().Do(ctx)
+		if err != nil {
+			return err
+		}
+		res, err := network.LoadNetworkResource(url, &network.LoadNetworkResourceOptions{
+			IncludeCredentials: true,
+		}).WithFrameID(tree.Frame.ID).Do(ctx)
+		if err != nil {
+			return err
+		}
+		if !res.Success {
+			return fmt.Errorf("status %d %s", int(res.HTTPStatusCode), res.NetErrorName)
+		}
+		if res.Stream == "" {
+			return fmt.Errorf("no stream")
+		}
+		defer cdpio.Close(res.Stream).Do(ctx)
+
+		// IO.read's Do drops the base64Encoded flag, so execute it directly
+		for {
+			var chunk cdpio.ReadReturns
+			if err := cdp.Execute(ctx, cdpio.CommandRead, cdpio.Read(res.Stream).WithSize(1<<20), &chunk); err != nil {
+				return err
+			}
+			if chunk.Base64encoded {
+				decoded, err := base64.StdEncoding.DecodeString(chunk.Data)
+				if err != nil {
+					return err
+				}
+				data = append(data, decoded...)
+			} else {
+				data = append(data, chunk.Data...)
+			}
+			if chunk.EOF {
+				return nil
+			}
+		}
+	}))
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
@@ -4,8 +4,10 @@ import (
 	"context"
 	"encoding/base64"
 	"fmt"
+	"log"
 	"math/rand"
 	"net/url"
+	"slices"
 	"strings"
 	"sync"
 	"time"
@@ -20,8 +22,17 @@ import (
 type FeedCursor struct {
 	ctx context.Context
 
+	// events receives EventSyncProgress while a SyncTo is retrying
+	events chan<- Event
+
 	mu  sync.RWMutex
 	pks []string
+	// reached is the highest index SyncTo has been asked for. Indices up to
+	// it (plus the prefetch window) are settled and never reordered.
+	reached int
+	// downloaded is the highest index Download has been asked for. Cached
+	// files are named by index, so everything up to it is settled too.
+	downloaded int
 
 	syncMu     sync.Mutex
 	syncCtx    context.Context
@@ -29,8 +40,8 @@ type FeedCursor struct {
 }
 
 // NewFeedCursor wires the cursor to the feed window's chromedp context.
-func NewFeedCursor(ctx context.Context) *FeedCursor {
-	return &FeedCursor{ctx: ctx}
+func NewFeedCursor(ctx context.Context, events chan<- Event) *FeedCursor {
+	return &FeedCursor{ctx: ctx, events: events}
 }
 
 // append records a newly captured PK at the tail. The caller (processReelResponse)
@@ -119,6 +130,11 @@ func (fc *FeedCursor) domPK() (string, error) {
 		return "", fmt.Errorf("no visible reel found")
 	}
 
+	return pkFromImgSrc(imgSrc)
+}
+
+// pkFromImgSrc decodes a reel pk from the ig_cache_key of a thumbnail URL.
+func pkFromImgSrc(imgSrc string) (string, error) {
 	matches := pkRegex.FindStringSubmatch(imgSrc)
 	if len(matches) < 2 {
 		return "", fmt.Errorf("no ig_cache_key found")
@@ -142,6 +158,86 @@ func (fc *FeedCursor) domPK() (string, error) {
 	return pk, nil
 }
 
+// domOrder returns the pks of every reel currently in the page, top to bottom.
+func (fc *FeedCursor) domOrder() ([]string, error) {
+	var srcs []string
+	js := `
+		(() => {
+			const out = [];
+			for (const video of document.querySelectorAll('video[playsinline]')) {
+				let parent = video.parentElement;
+				for (let i = 0; i < 12; i++) {
+					if (!parent) break;
+					const img = parent.querySelector('img[src*="ig_cache_key"]');
+					if (img) { out.push(img.src); break; }
+					parent = parent.parentElement;
+				}
+			}
+			return out;
+		})()
+	`
+	if err := chromedp.Run(fc.ctx, chromedp.Evaluate(js, &srcs)); err != nil {
+		return nil, err
+	}
+
+	pks := make([]string, 0, len(srcs))
+	for _, src := range srcs {
+		if pk, err := pkFromImgSrc(src); err == nil {
+			pks = append(pks, pk)
+		}
+	}
+	return pks, nil
+}
+
+// reconcile reorders captured reels past the settled prefix to match their
+// order in the page. Instagram can deliver clip responses out of order (e.g.
+// on a refresh), and SyncTo's index-based scrolling assumes the captured list
+// matches the DOM. Reels not in the page keep their slots.
+func (fc *FeedCursor) reconcile() {
+	order, err := fc.domOrder()
+	if err != nil || len(order) < 2 {
+		return
+	}
+	pos := make(map[string]int, len(order))
+	for i, pk := range order {
+		pos[pk] = i
+	}
+
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+
+	// the reel being watched, the two after it and every reel already
+	// downloaded (prefetch skips hidden reels, so it can run further ahead)
+	// stay put
+	settled := min(max(fc.reached+2, fc.downloaded), len(fc.pks))
+	var slots []int
+	var pks []string
+	for i := settled; i < len(fc.pks); i++ {
+		if _, ok := pos[fc.pks[i]]; ok {
+			slots = append(slots, i)
+			pks = append(pks, fc.pks[i])
+		}
+	}
+	sorted := slices.SortedStableFunc(slices.Values(pks), func(a, b string) int {
+		return pos[a] - pos[b]
+	})
+	if slices.Equal(pks, sorted) {
+		return
+	}
+	for i, slot := range slots {
+		fc.pks[slot] = sorted[i]
+	}
+	log.Printf("feed: reordered %d captured reels to match the page", len(slots))
+}
+
+// markDownloaded settles index before Download looks up its reel, so
+// reconcile can't move a reel whose files are named after its index.
+func (fc *FeedCursor) markDownloaded(index int) {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	fc.downloaded = max(fc.downloaded, index)
+}
+
 // scrollDown sends a single ArrowDown to advance to the next reel.
 func (fc *FeedCursor) scrollDown() error {
 	return chromedp.Run(fc.ctx,
@@ -170,6 +266,33 @@ func (fc *FeedCursor) scrollUp() error {
 	)
 }
 
+// scrollFallback scrolls the feed without relying on keyboard focus. It
+// scrolls the video after (direction > 0) or before the visible one into
+// view, or falls back to scrolling the window by one viewport height.
+func (fc *FeedCursor) scrollFallback(direction int) error {
+	js := fmt.Sprintf(`
+		(() => {
+			const dir = %d;
+			const videos = Array.from(document.querySelectorAll('video[playsinline]'));
+			const current = videos.findIndex(video => {
+				const rect = video.getBoundingClientRect();
+				const videoCenter = rect.top + rect.height / 2;
+				return videoCenter > 0 && videoCenter < window.innerHeight;
+			});
+			const target = current === -1 ? null : videos[current + dir];
+			if (target) {
+				target.scrollIntoView({block: 'center'});
+			} else {
+				window.scrollBy(0, dir * window.innerHeight);
+			}
+			return true;
+		})()
+	`, direction)
+
+	var ok bool
+	return chromedp.Run(fc.ctx, chromedp.Evaluate(js, &ok))
+}
+
 // SyncTo scrolls the feed window until the reel at index is the visible one.
 // Cancels any in-flight SyncTo so a newer one can supersede it.
 func (fc *FeedCursor) SyncTo(index int) error {
@@ -177,7 +300,14 @@ func (fc *FeedCursor) SyncTo(index int) error {
 	if fc.syncCancel != nil {
 		fc.syncCancel()
 	}
-	ctx, cancel := context.WithCancel(fc.ctx)
+	retries, timeout := syncLimits()
+	var ctx context.Context
+	var cancel context.CancelFunc
+	if timeout > 0 {
+		ctx, cancel = context.WithTimeout(fc.ctx, timeout)
+	} else {
+		ctx, cancel = context.WithCancel(fc.ctx)
+	}
 	fc.syncCtx = ctx
 	fc.syncCancel = cancel
 	fc.syncMu.Unlock()
@@ -186,31 +316,37 @@ func (fc *FeedCursor) SyncTo(index int) error {
 
 	currentPK, _ := fc.domPK()
 
-	fc.mu.RLock()
+	fc.mu.Lock()
 	if index < 1 || index > len(fc.pks) {
-		fc.mu.RUnlock()
+		fc.mu.Unlock()
 		return fmt.Errorf("index %d out of range", index)
 	}
 	targetPK := fc.pks[index-1]
+	fc.reached = max(fc.reached, index)
+	fc.mu.Unlock()
 	if currentPK == targetPK {
-		fc.mu.RUnlock()
 		return nil
 	}
-	currentIndex := 0
-	for i, pk := range fc.pks {
-		if pk == currentPK {
-			currentIndex = i + 1
-			break
-		}
-	}
-	fc.mu.RUnlock()
+	currentIndex := fc.indexOf(currentPK)
+
+	// stalls counts consecutive key scrolls that didn't change the visible
+	// reel. Past KeyScrollStallLimit the page likely lost keyboard focus, so
+	// scrolling switches to the JS fallback.
+	lastPK := currentPK
+	stalls := 0
 
-	for i := 0; i < MaxRetries; i++ {
+	for i := 0; i < retries; i++ {
 		select {
 		case <-ctx.Done():
+			if ctx.Err() == context.DeadlineExceeded {
+				return fmt.Errorf("timed out syncing to index %d after %d scrolls", index, i)
+			}
 			return nil
 		default:
 		}
+		if i > 0 {
+			emitSyncProgress(fc.events, i+1, retries)
+		}
 
 		pk, err := fc.domPK()
 		if err == nil && pk == targetPK {
@@ -223,25 +359,42 @@ func (fc *FeedCursor) SyncTo(index int) error {
 			}
 		}
 
-		if currentIndex < index {
-			if err := fc.scrollDown(); err != nil {
+		if i > 0 {
+			if pk == lastPK {
+				stalls++
+			} else {
+				stalls = 0
+			}
+		}
+		lastPK = pk
+
+		// index matches but PK doesn't; try scrolling down to recover
+		direction := 1
+		if currentIndex > index {
+			direction = -1
+		}
+
+		if stalls >= KeyScrollStallLimit {
+			if err := fc.scrollFallback(direction); err != nil {
 				return err
 			}
-		} else if currentIndex > index {
-			if err := fc.scrollUp(); err != nil {
+		} else if direction > 0 {
+			if err := fc.scrollDown(); err != nil {
 				return err
 			}
 		} else {
-			// index matches but PK doesn't; try scrolling down to recover
-			if err := fc.scrollDown(); err != nil {
+			if err := fc.scrollUp(); err != nil {
 				return err
 			}
 		}
 
-		time.Sleep(time.Duration(1500+rand.Intn(500)) * time.Millisecond)
+		select {
+		case <-ctx.Done():
+		case <-time.After(time.Duration(1500+rand.Intn(500)) * time.Millisecond):
+		}
 	}
 
-	return fmt.Errorf("failed to sync to index %d after %d scrolls", index, MaxRetries)
+	return fmt.Errorf("failed to sync to index %d after %d scrolls", index, retries)
 }
 
 // IsSyncing returns true if a SyncTo is in flight (its derived ctx not yet done).
@@ -5,8 +5,10 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"log"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/chromedp/cdproto/fetch"
 	"github.com/chromedp/cdproto/runtime"
@@ -53,7 +55,9 @@ type reelMedia struct {
 	CommentCount     int    `json:"comment_count"`
 	MediaRepostCount int    `json:"media_repost_count"`
 	VideoVersions    []struct {
-		URL string `json:"url"`
+		URL    string `json:"url"`
+		Width  int    `json:"width"`
+		Height int    `json:"height"`
 	} `json:"video_versions"`
 	User struct {
 		Username      string `json:"username"`
@@ -106,8 +110,16 @@ type reelResponse struct {
 // called from any path that has a reelMedia in hand.
 func buildReel(media reelMedia) *Reel {
 	var videoURL string
-	if len(media.VideoVersions) > 0 {
-		videoURL = strings.ReplaceAll(media.VideoVersions[0].URL, "\\u0026", "&")
+	versions := make([]VideoVersion, 0, len(media.VideoVersions))
+	for _, v := range media.VideoVersions {
+		versions = append(versions, VideoVersion{
+			URL:    strings.ReplaceAll(v.URL, "\\u0026", "&"),
+			Width:  v.Width,
+			Height: v.Height,
+		})
+	}
+	if len(versions) > 0 {
+		videoURL = versions[0].URL
 	}
 
 	caption := ""
@@ -144,6 +156,7 @@ func buildReel(media reelMedia) *Reel {
 		PK:                   media.PK,
 		Code:                 media.Code,
 		VideoURL:             videoURL,
+		VideoVersions:        versions,
 		ProfilePicUrl:        media.User.ProfilePicUrl,
 		Username:             media.User.Username,
 		Caption:              caption,
@@ -274,6 +287,83 @@ func execGraphQL(req graphQLRequest) (string, error) {
 	return result, nil
 }
 
+// fetchMediaInfo GETs /api/v1/media/<pk>/info/ as an in-page fetch() (so the
+// session cookies ride along) and returns the media it describes.
+func fetchMediaInfo(ctx context.Context, pk string) (*reelMedia, error) {
+	endpoint := "https://www.instagram.com/api/v1/media/" + url.PathEscape(pk) + "/info/"
+	js := fmt.Sprintf(`
+		(async () => {
+			const ac = new AbortController();
+			const tid = setTimeout(() => ac.abort(), 10000);
+			try {
+				const r = await fetch(%s, {
+					headers: { "x-ig-app-id": %s },
+					credentials: "include",
+					signal: ac.signal
+				});
+				return await r.text();
+			} finally {
+				clearTimeout(tid);
+			}
+		})()
+	`, jsonStringForJS(endpoint), expectedAppID)
+
+	var result string
+	if err := chromedp.Run(ctx, chromedp.Evaluate(js, &result, func(p *runtime.EvaluateParams) *runtime.EvaluateParams {
+		return p.WithAwaitPromise(true)
+	})); err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		Items []reelMedia `json:"items"`
+	}
+	if err := json.Unmarshal([]byte(result), &resp); err != nil {
+		return nil, fmt.Errorf("media info: %w", err)
+	}
+	if len(resp.Items) == 0 {
+		return nil, fmt.Errorf("media info: no item for pk=%s", pk)
+	}
+	return &resp.Items[0], nil
+}
+
+// postWebAPI POSTs to one of instagram's /api/v1/web/ endpoints as an
+// in-page fetch() with the session's CSRF token and returns the response body.
+func postWebAPI(ctx context.Context, endpoint string) (string, error) {
+	js := fmt.Sprintf(`
+		(async () => {
+			const ac = new AbortController();
+			const tid = setTimeout(() => ac.abort(), 10000);
+			try {
+				const csrftoken = document.cookie.split('; ')
+					.find(c => c.startsWith('csrftoken='))
+					?.split('=')[1] || '';
+				const r = await fetch(%s, {
+					method: "POST",
+					headers: {
+						"content-type": "application/x-www-form-urlencoded",
+						"x-csrftoken": csrftoken,
+						"x-ig-app-id": %s,
+					},
+					credentials: "include",
+					signal: ac.signal
+				});
+				return await r.text();
+			} finally {
+				clearTimeout(tid);
+			}
+		})()
+	`, jsonStringForJS(endpoint), expectedAppID)
+
+	var result string
+	if err := chromedp.Run(ctx, chromedp.Evaluate(js, &result, func(p *runtime.EvaluateParams) *runtime.EvaluateParams {
+		return p.WithAwaitPromise(true)
+	})); err != nil {
+		return "", err
+	}
+	return result, nil
+}
+
 // processReelResponse extracts reels from a GraphQL response. New PKs are
 // inserted into b.reels and appended to the feed cursor; map membership is
 // the dedup signal.
@@ -283,6 +373,7 @@ func (b *ChromeBackend) processReelResponse(body string) {
 		return
 	}
 
+	added := 0
 	for _, edge := range resp.Data.Connection.Edges {
 		media := edge.Node.Media
 		if media.PK == "" {
@@ -297,6 +388,17 @@ func (b *ChromeBackend) processReelResponse(body string) {
 		b.reels[media.PK] = buildReel(media)
 		b.feed.append(media.PK)
 		b.reelsMu.Unlock()
+		added++
+	}
+
+	// keep capture order consistent with the page; runs off the fetch
+	// listener since it evaluates JS
+	if added > 0 {
+		go b.feed.reconcile()
+	}
+
+	if b.dumpTarget > 0 && b.feed.Total() >= b.dumpTarget && b.dumpDone.CompareAndSwap(false, true) {
+		b.events <- Event{Type: EventCaptureComplete, Count: b.feed.Total()}
 	}
 }
 
@@ -312,20 +414,74 @@ func decodePostData(e *fetch.EventRequestPaused) string {
 	return string(raw)
 }
 
-// processFeedGraphQLBody is the fetch interception router for the dm browser.
-func (b *ChromeBackend) processDMGraphQLBody(ctx context.Context, e *fetch.EventRequestPaused) {
+// isLoginRequired reports whether an Instagram API response says the
+// session is logged out.
+func isLoginRequired(body string) bool {
+	return strings.Contains(body, `"require_login":true`)
+}
+
+// Retries for reading an intercepted response body. Under load the read can
+// fail if it races the request's resumption; the delays stay well inside the
+// paused request's timeout.
+const (
+	bodyReadAttempts = 3
+	bodyReadBackoff  = 50 * time.Millisecond // doubled after each failure
+)
+
+// fetchResponseBody reads the body of a paused response from Chrome.
+func fetchResponseBody(ctx context.Context, id fetch.RequestID) ([]byte, error) {
 	var body []byte
 	err := chromedp.Run(ctx,
 		chromedp.ActionFunc(func(c context.Context) error {
-			data, err := fetch.GetResponseBody(e.RequestID).Do(c)
-			if err != nil {
-				return err
-			}
+			data, err := fetch.GetResponseBody(id).Do(c)
 			body = data
-			return nil
+			return err
 		}),
 	)
-	if err != nil {
+	return body, err
+}
+
+// responseBody reads the body of a paused response, retrying with backoff.
+// After the last failure the response's data is lost, so the first time that
+// happens EventResponsesLost tells the user some reels or comments may be
+// missing.
+func (b *ChromeBackend) responseBody(ctx context.Context, id fetch.RequestID) ([]byte, bool) {
+	delay := bodyReadBackoff
+	var err error
+	for attempt := 1; ; attempt++ {
+		var body []byte
+		body, err = b.fetchBody(ctx, id)
+		if err == nil {
+			return body, true
+		}
+		if attempt == bodyReadAttempts || ctx.Err() != nil {
+			break
+		}
+		time.Sleep(delay)
+		delay *= 2
+	}
+
+	log.Printf("read response body %s: %v", id, err)
+	if ctx.Err() == nil && b.bodyWarned.CompareAndSwap(false, true) {
+		b.events <- Event{Type: EventResponsesLost}
+	}
+	return nil, false
+}
+
+// continueRequest resumes a paused request.
+func (b *ChromeBackend) continueRequest(ctx context.Context, id fetch.RequestID) {
+	chromedp.Run(ctx,
+		chromedp.ActionFunc(func(c context.Context) error {
+			return fetch.ContinueRequest(id).Do(c)
+		}),
+	)
+}
+
+// processFeedGraphQLBody is the fetch interception router for the dm browser.
+func (b *ChromeBackend) processDMGraphQLBody(ctx context.Context, e *fetch.EventRequestPaused) {
+	body, ok := b.responseBody(ctx, e.RequestID)
+	if !ok {
+		b.continueRequest(ctx, e.RequestID)
 		return
 	}
 	bodyStr := string(body)
@@ -342,31 +498,20 @@ func (b *ChromeBackend) processDMGraphQLBody(ctx context.Context, e *fetch.Event
 		}
 
 	}
-	chromedp.Run(ctx,
-		chromedp.ActionFunc(func(c context.Context) error {
-			return fetch.ContinueRequest(e.RequestID).Do(c)
-		}),
-	)
+	b.continueRequest(ctx, e.RequestID)
 }
 
 // processFeedGraphQLBody is the fetch interception router for the regular reel browser.
 func (b *ChromeBackend) processFeedGraphQLBody(ctx context.Context, e *fetch.EventRequestPaused) {
-	var body []byte
-	err := chromedp.Run(ctx,
-		chromedp.ActionFunc(func(c context.Context) error {
-			data, err := fetch.GetResponseBody(e.RequestID).Do(c)
-			if err != nil {
-				return err
-			}
-			body = data
-			return nil
-		}),
-	)
-	if err != nil {
+	body, ok := b.responseBody(ctx, e.RequestID)
+	if !ok {
+		b.continueRequest(ctx, e.RequestID)
 		return
 	}
 	bodyStr := string(body)
 	switch {
+	case isLoginRequired(bodyStr):
+		b.notifyLoginRequired()
 	case strings.Contains(bodyStr, "xdt_api__v1__clips__home__connection_v2"):
 		b.dm.CaptureTemplate(decodePostData(e))
 		b.processReelResponse(bodyStr)
@@ -378,9 +523,5 @@ func (b *ChromeBackend) processFeedGraphQLBody(ctx context.Context, e *fetch.Eve
 		}
 	}
 
-	chromedp.Run(ctx,
-		chromedp.ActionFunc(func(c context.Context) error {
-			return fetch.ContinueRequest(e.RequestID).Do(c)
-		}),
-	)
+	b.continueRequest(ctx, e.RequestID)
 }
@@ -0,0 +1,89 @@
+package backend
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/chromedp/cdproto/fetch"
+)
+
+// flakyBody returns a fetchBody that fails the first failures calls, then
+// returns body. calls counts every call.
+func flakyBody(failures int, body string, calls *int) func(context.Context, fetch.RequestID) ([]byte, error) {
+	return func(context.Context, fetch.RequestID) ([]byte, error) {
+		*calls++
+		if *calls <= failures {
+			return nil, errors.New("No data found for resource with given identifier")
+		}
+		return []byte(body), nil
+	}
+}
+
+func TestResponseBodyRetriesTransientFailure(t *testing.T) {
+	calls := 0
+	b := &ChromeBackend{
+		events:    make(chan Event, 1),
+		fetchBody: flakyBody(bodyReadAttempts-1, "{}", &calls),
+	}
+
+	body, ok := b.responseBody(context.Background(), "req-1")
+	if !ok || string(body) != "{}" {
+		t.Fatalf("responseBody = %q, %v; want the body after retrying", body, ok)
+	}
+	if calls != bodyReadAttempts {
+		t.Errorf("fetched %d times, want %d", calls, bodyReadAttempts)
+	}
+	select {
+	case ev := <-b.events:
+		t.Errorf("unexpected event %v after a successful retry", ev.Type)
+	default:
+	}
+}
+
+func TestResponseBodyReportsLostResponseOnce(t *testing.T) {
+	calls := 0
+	b := &ChromeBackend{
+		events:    make(chan Event, 2),
+		fetchBody: flakyBody(2*bodyReadAttempts, "", &calls),
+	}
+
+	for range 2 {
+		if _, ok := b.responseBody(context.Background(), "req-1"); ok {
+			t.Fatal("responseBody succeeded, want every attempt to fail")
+		}
+	}
+	if calls != 2*bodyReadAttempts {
+		t.Errorf("fetched %d times, want %d", calls, 2*bodyReadAttempts)
+	}
+	if ev := <-b.events; ev.Type != EventResponsesLost {
+		t.Errorf("event = %v, want EventResponsesLost", ev.Type)
+	}
+	select {
+	case ev := <-b.events:
+		t.Errorf("second event %v, want EventResponsesLost only once", ev.Type)
+	default:
+	}
+}
+
+func TestResponseBodyQuietWhenCanceled(t *testing.T) {
+	calls := 0
+	b := &ChromeBackend{
+		events:    make(chan Event, 1),
+		fetchBody: flakyBody(bodyReadAttempts, "", &calls),
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, ok := b.responseBody(ctx, "req-1"); ok {
+		t.Fatal("responseBody succeeded, want a failure")
+	}
+	if calls != 1 {
+		t.Errorf("fetched %d times, want no retries once the context is done", calls)
+	}
+	select {
+	case ev := <-b.events:
+		t.Errorf("unexpected event %v while shutting down", ev.Type)
+	default:
+	}
+}
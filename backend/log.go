@@ -2,19 +2,32 @@ package backend
 
 import (
 	"fmt"
+	"io"
+	"log"
 	"log/slog"
 	"os"
 	"path/filepath"
 	"strings"
 )
 
-// InitLogger configures the default slog logger to write to logDir/reels.log.
-func InitLogger(logDir string) error {
+// InitLogger configures the default slog logger to write to logDir/reels.log
+// when debug (--debug) is set, and to discard everything otherwise. The last
+// run's log is kept as reels.log.1, so a log survives restarting after a
+// crash without growing across runs.
+func InitLogger(logDir string, debug bool) error {
+	if !debug {
+		// the default slog handler writes through the log package, so this
+		// silences both, and keeps log.Printf off the TUI's screen
+		log.SetOutput(io.Discard)
+		return nil
+	}
 	if err := os.MkdirAll(logDir, 0755); err != nil {
 		return err
 	}
 
-	f, err := os.OpenFile(filepath.Join(logDir, "reels.log"), os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	path := filepath.Join(logDir, "reels.log")
+	os.Rename(path, path+".1")
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
 	if err != nil {
 		return err
 	}
@@ -2,51 +2,135 @@ package backend
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
 	"io"
+	"log"
 	"net/http"
 	"os"
 	"path/filepath"
 	goruntime "runtime"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
+	"syscall"
 	"time"
 )
 
 type Settings struct {
-	ShowNavbar       bool
-	RetinaScale      int
-	ReelWidth        int
-	ReelHeight       int
-	ReelSizeStep     int
-	Volume           float64
-	GifCellHeight    int
-	PanelShrinkSteps int
-
-	KeysNext         []string
-	KeysPrevious     []string
-	KeysMute         []string
-	KeysPause        []string
-	KeysLike         []string
-	KeysRepost       []string
-	KeysNavbar       []string
-	KeysReelSizeInc  []string
-	KeysReelSizeDec  []string
-	KeysVolUp        []string
-	KeysVolDown      []string
-	KeysQuit         []string
-	KeysCopyLink     []string
-	KeysSave         []string
-	KeysSeekForward  []string
-	KeysSeekBackward []string
-	KeysSelect       []string
+	ShowNavbar        bool
+	Headed            bool
+	Resume            bool
+	ShowCounts        bool
+	GlyphLike         string
+	GlyphLiked        string
+	GlyphComment      string
+	GlyphVerified     string
+	GlyphExplicit     string
+	GlyphHeart        string
+	GlyphRepost       string
+	GlyphSaved        string
+	GlyphUnsaved      string
+	HideExplicit      bool
+	ResumePosition    bool
+	JumpSize          int // reels key_jump_forward/back move
+	NavbarCompact     bool
+	PfpPosition       string // "bottomleft", "topleft", "topright" or "bottomright"
+	ShowProfilePic    bool
+	RetinaScale       int
+	FallbackCellW     int // fallback_cell_width
+	FallbackCellH     int // fallback_cell_height
+	ReelWidth         int
+	ReelHeight        int
+	ReelSizeStep      int
+	FitMode           string // "contain", "cover" or "stretch"
+	VideoOffsetRows   int
+	VideoOffsetCols   int
+	Volume            float64
+	Muted             bool
+	GifCellHeight     int
+	CommentIndent     int
+	CommentSeparator  string // "none", "blank" or "line"
+	HighlightHashtags bool
+	PanelShrinkSteps  int
+	AudioFadeMs       int
+	NormalizeAudio    bool
+	AudioBufferMs     int
+	FrameBuffer       int
+	SyncThresholdMs   int
+	SyncMode          string // "drop", "sleep" or "adaptive"
+	SyncUpdate        string // "auto", "on" or "off"
+	Renderer          string // "auto", "kitty", "sixel" or "halfblock"
+	CachePolicy       string // "fifo" or "lru"
+	CacheSize         int    // reels whose video and pfps are kept on disk
+	DownloadDir       string // where key_export saves reels; ~ is the home directory
+	ExportPfp         bool
+	DownloadStrategy  string // "auto", "http", "headers" or "cdp"
+	VideoQuality      string // "low" or "high"
+	MaxDownloads      int    // max_concurrent_downloads
+	Prewarm           bool
+	HoldFrame         bool
+	AnimatedPfp       bool
+	AudioMeter        bool
+	LikeAnimation     bool
+	NightBrightness   float64
+	Brightness        float64
+	SyncMaxRetries    int
+	SyncTimeoutMs     int
+	CommentsTimeoutMs int
+	PageLoadTimeoutMs int
+	ResizeDebounceMs  int
+	AutoplayDwell     int // autoplay_dwell_seconds
+	LoopCount         int
+
+	KeysNext          []string
+	KeysPrevious      []string
+	KeysReelNext      []string
+	KeysReelPrevious  []string
+	KeysJumpForward   []string
+	KeysJumpBack      []string
+	KeysMute          []string
+	KeysPause         []string
+	KeysLike          []string
+	KeysRepost        []string
+	KeysNavbar        []string
+	KeysNavbarCompact []string
+	KeysReelSizeInc   []string
+	KeysReelSizeDec   []string
+	KeysVolUp         []string
+	KeysVolDown       []string
+	KeysBrightUp      []string
+	KeysBrightDown    []string
+	KeysQuit          []string
+	KeysCopyLink      []string
+	KeysOpenBrowser   []string
+	KeysCopyComments  []string
+	KeysCopyText      []string
+	KeysRefreshReel   []string
+	KeysSave          []string
+	KeysExport        []string
+	KeysSeekForward   []string
+	KeysSeekBackward  []string
+	KeysFrameForward  []string
+	KeysSelect        []string
+	KeysScrollCaption []string
+	KeysFocus         []string
+	KeysGrid          []string
+	KeysAutoplay      []string
+	KeysFilterMusic   []string
+	KeysFeedOrder     []string
+	KeysNightMode     []string
+	KeysRecheckLogin  []string
+	KeysRetry         []string
 
 	KeysShareOpen  []string
 	KeysShareClose []string
 
 	KeysCommentsOpen  []string
 	KeysCommentsClose []string
+	KeysLikeComment   []string
+	KeysCommentSort   []string
 
 	KeysHelpOpen  []string
 	KeysHelpClose []string
@@ -80,17 +164,27 @@ func GetSettings() Settings {
 }
 
 // fifoCache is a bounded FIFO that evicts the oldest entry (and its file) when full.
+// With lru set, touch moves an entry to the back so recently used files are
+// evicted last.
 type fifoCache struct {
 	mu   sync.Mutex
 	list []string
 	set  map[string]bool
 	max  int
+	lru  bool
+
+	// pinned is never evicted, see pin
+	pinned string
+
+	// onEvict, if set, runs for each evicted path after its file is removed
+	onEvict func(path string)
 }
 
-func newFIFOCache(max int) *fifoCache {
+func newFIFOCache(max int, lru bool) *fifoCache {
 	return &fifoCache{
 		set: make(map[string]bool),
 		max: max,
+		lru: lru,
 	}
 }
 
@@ -100,18 +194,109 @@ func (c *fifoCache) has(path string) bool {
 	return c.set[path]
 }
 
-func (c *fifoCache) add(path string) {
+// touch marks path as just used. No-op for FIFO caches or paths not cached.
+func (c *fifoCache) touch(path string) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
+	if !c.lru || !c.set[path] {
+		return
+	}
+	i := slices.Index(c.list, path)
+	c.list = append(append(c.list[:i:i], c.list[i+1:]...), path)
+}
+
+func (c *fifoCache) add(path string) {
+	c.mu.Lock()
 	if c.set[path] {
+		c.mu.Unlock()
 		return
 	}
 	c.list = append(c.list, path)
 	c.set[path] = true
-	for len(c.list) > c.max {
-		os.Remove(c.list[0])
-		delete(c.set, c.list[0])
-		c.list = c.list[1:]
+	evicted := c.dropOldest(len(c.list) - c.max)
+	onEvict := c.onEvict
+	c.mu.Unlock()
+
+	if onEvict != nil {
+		for _, p := range evicted {
+			onEvict(p)
+		}
+	}
+}
+
+// pin keeps path out of eviction until another path is pinned. The playing
+// reel's video is pinned because playback reopens the file on every loop.
+func (c *fifoCache) pin(path string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.pinned = path
+}
+
+// dropOldest removes up to n of the oldest entries (and their files), skipping
+// the pinned one. Returns the removed paths. Caller must hold c.mu.
+func (c *fifoCache) dropOldest(n int) []string {
+	var evicted []string
+	kept := c.list[:0]
+	for _, p := range c.list {
+		if len(evicted) < n && p != c.pinned {
+			os.Remove(p)
+			delete(c.set, p)
+			evicted = append(evicted, p)
+			continue
+		}
+		kept = append(kept, p)
+	}
+	c.list = kept
+	return evicted
+}
+
+// resize changes the cache's capacity, evicting the oldest entries if it now
+// holds more than that.
+func (c *fifoCache) resize(max int) {
+	c.mu.Lock()
+	c.max = max
+	excess := len(c.list) - max
+	c.mu.Unlock()
+
+	if excess > 0 {
+		c.evictOldest(excess)
+	}
+}
+
+// capacity returns how many entries the cache holds before evicting.
+func (c *fifoCache) capacity() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.max
+}
+
+// evictOldest removes the n oldest entries (and their files) to free disk
+// space, skipping the pinned one. Returns how many were evicted.
+func (c *fifoCache) evictOldest(n int) int {
+	c.mu.Lock()
+	evicted := c.dropOldest(n)
+	onEvict := c.onEvict
+	c.mu.Unlock()
+
+	if onEvict != nil {
+		for _, p := range evicted {
+			onEvict(p)
+		}
+	}
+	return len(evicted)
+}
+
+// remove deletes path's file and drops it from the cache, if present.
+func (c *fifoCache) remove(path string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.set[path] {
+		return
+	}
+	os.Remove(path)
+	delete(c.set, path)
+	if i := slices.Index(c.list, path); i >= 0 {
+		c.list = slices.Delete(c.list, i, i+1)
 	}
 }
 
@@ -132,36 +317,76 @@ var (
 )
 
 func (b *ChromeBackend) initStorage() error {
-	videoCache = newFIFOCache(ReelCacheSize)
-	reelPfpCache = newFIFOCache(ReelCacheSize)
-	sharePfpCache = newFIFOCache(SharePfpCacheSize)
-	gifCache = newFIFOCache(GifCacheSize)
-	dmPfpCache = newFIFOCache(DMPfpCacheSize)
+	lru := GetSettings().CachePolicy == "lru"
+	size := GetSettings().CacheSize
+	if size < 1 {
+		size = ReelCacheSize
+	}
+	videoCache = newFIFOCache(size, lru)
+	reelPfpCache = newFIFOCache(size, lru)
+	sharePfpCache = newFIFOCache(SharePfpCacheSize, lru)
+	gifCache = newFIFOCache(GifCacheSize, lru)
+	dmPfpCache = newFIFOCache(DMPfpCacheSize, lru)
+	videoCache.onEvict = b.evictReelGifs
 	inProgress = make(map[string]chan struct{})
 	liked = make(map[string]bool)
 
 	// clear cache on startup
 	if err := os.RemoveAll(b.cacheDir); err != nil {
-		return fmt.Errorf("could not delete old cache directory")
+		return fmt.Errorf("could not delete old cache directory: %w", err)
 	}
 	if err := os.MkdirAll(b.cacheDir, 0755); err != nil {
-		return fmt.Errorf("could not create new cache directory")
+		return fmt.Errorf("could not create new cache directory: %w", err)
 	}
 
 	// ensure config directory exists
 	if err := os.MkdirAll(b.configDir, 0755); err != nil {
-		return fmt.Errorf("could not create config directory")
+		return fmt.Errorf("could not create config directory: %w", err)
 	}
 
 	// write default settings if settings file doesn't exist
 	settingsPath := filepath.Join(b.configDir, "reels.conf")
 	if _, err := os.Stat(settingsPath); os.IsNotExist(err) {
-		writeConf(settingsPath, defaultSettings())
+		b.saveConf(defaultSettings())
 	}
 
 	return nil
 }
 
+// evictReelGifs deletes the comment GIFs of the reel whose video file was just
+// evicted, so they don't linger in the cache until gifCache fills up.
+func (b *ChromeBackend) evictReelGifs(videoFile string) {
+	// video files are named %03d_<code>.mp4, see Download
+	name := strings.TrimSuffix(filepath.Base(videoFile), ".mp4")
+	_, code, ok := strings.Cut(name, "_")
+	if !ok {
+		return
+	}
+
+	b.reelsMu.Lock()
+	defer b.reelsMu.Unlock()
+	for _, r := range b.reels {
+		if r.Code != code {
+			continue
+		}
+		for i := range r.Comments {
+			if r.Comments[i].GifPath != "" {
+				gifCache.remove(r.Comments[i].GifPath)
+				r.Comments[i].GifPath = ""
+			}
+		}
+	}
+}
+
+// sweepGifs removes every comment GIF left in the cache directory.
+func (b *ChromeBackend) sweepGifs() {
+	paths, _ := filepath.Glob(filepath.Join(b.cacheDir, "gif_*.gif"))
+	for _, p := range paths {
+		gifCache.remove(p)
+		os.Remove(p)
+	}
+}
+
 // cacheGif writes GIF data to the cache directory with FIFO eviction.
 func (b *ChromeBackend) cacheGif(pk string, data []byte) string {
 	path := filepath.Join(b.cacheDir, fmt.Sprintf("gif_%s.gif", pk))
@@ -206,37 +431,103 @@ func (b *ChromeBackend) cacheSharePfp(name string, data []byte) string {
 
 func defaultSettings() Settings {
 	s := Settings{
-		ShowNavbar:       true,
-		RetinaScale:      1,
-		ReelWidth:        270,
-		ReelHeight:       480,
-		ReelSizeStep:     30,
-		Volume:           1,
-		GifCellHeight:    5,
-		PanelShrinkSteps: 4,
-		KeysNext:         []string{"j"},
-		KeysPrevious:     []string{"k"},
-		KeysPause:        []string{"p"},
-		KeysMute:         []string{"m"},
-		KeysLike:         []string{" "},
-		KeysRepost:       []string{"r"},
-		KeysNavbar:       []string{"e"},
-		KeysReelSizeInc:  []string{"="},
-		KeysReelSizeDec:  []string{"-"},
-		KeysVolUp:        []string{"]"},
-		KeysVolDown:      []string{"["},
-		KeysQuit:         []string{"q", "ctrl+c"},
-		KeysCopyLink:     []string{"y"},
-		KeysSave:         []string{"b"},
-		KeysSeekForward:  []string{"l"},
-		KeysSeekBackward: []string{"h"},
-		KeysSelect:       []string{" "},
+		ShowNavbar:        true,
+		ShowCounts:        true,
+		GlyphLike:         "🤍",
+		GlyphLiked:        "❤️",
+		GlyphComment:      "💬",
+		GlyphVerified:     "✓",
+		GlyphExplicit:     "[E]",
+		GlyphHeart:        "♥",
+		GlyphRepost:       "⇄",
+		GlyphSaved:        "⚑",
+		GlyphUnsaved:      "⚐",
+		NavbarCompact:     false,
+		JumpSize:          5,
+		RetinaScale:       1,
+		FallbackCellW:     10,
+		FallbackCellH:     20,
+		ReelWidth:         270,
+		ReelHeight:        480,
+		ReelSizeStep:      30,
+		FitMode:           "contain",
+		Volume:            1,
+		GifCellHeight:     5,
+		CommentIndent:     2,
+		CommentSeparator:  "none",
+		PanelShrinkSteps:  4,
+		AudioFadeMs:       0,
+		AudioBufferMs:     50,
+		FrameBuffer:       3,
+		SyncThresholdMs:   100,
+		SyncMode:          "drop",
+		SyncUpdate:        "auto",
+		Renderer:          "auto",
+		PfpPosition:       "bottomleft",
+		ShowProfilePic:    true,
+		CachePolicy:       "fifo",
+		CacheSize:         ReelCacheSize,
+		DownloadDir:       "~/Downloads/reels",
+		DownloadStrategy:  "auto",
+		VideoQuality:      "low",
+		MaxDownloads:      6,
+		Prewarm:           false,
+		HoldFrame:         true,
+		LikeAnimation:     true,
+		NightBrightness:   0.5,
+		Brightness:        1,
+		SyncMaxRetries:    MaxRetries,
+		SyncTimeoutMs:     60000,
+		CommentsTimeoutMs: 10000,
+		PageLoadTimeoutMs: 10000,
+		ResizeDebounceMs:  100,
+		KeysNext:          []string{"j"},
+		KeysPrevious:      []string{"k"},
+		KeysReelNext:      []string{"J"},
+		KeysReelPrevious:  []string{"K"},
+		KeysJumpForward:   []string{"ctrl+d"},
+		KeysJumpBack:      []string{"ctrl+u"},
+		KeysPause:         []string{"p"},
+		KeysMute:          []string{"m"},
+		KeysLike:          []string{" "},
+		KeysRepost:        []string{"r"},
+		KeysNavbar:        []string{"e"},
+		KeysNavbarCompact: []string{"E"},
+		KeysReelSizeInc:   []string{"="},
+		KeysReelSizeDec:   []string{"-"},
+		KeysVolUp:         []string{"]"},
+		KeysVolDown:       []string{"["},
+		KeysBrightUp:      []string{"}"},
+		KeysBrightDown:    []string{"{"},
+		KeysQuit:          []string{"q", "ctrl+c"},
+		KeysCopyLink:      []string{"y"},
+		KeysOpenBrowser:   []string{"B"},
+		KeysCopyComments:  []string{"Y"},
+		KeysCopyText:      []string{"T"},
+		KeysRefreshReel:   []string{"R"},
+		KeysSave:          []string{"b"},
+		KeysExport:        []string{"w"},
+		KeysSeekForward:   []string{"l", "right"},
+		KeysSeekBackward:  []string{"h", "left"},
+		KeysFrameForward:  []string{"."},
+		KeysSelect:        []string{" "},
+		KeysScrollCaption: []string{"t"},
+		KeysFocus:         []string{"f"},
+		KeysGrid:          []string{"g"},
+		KeysAutoplay:      []string{"a"},
+		KeysFilterMusic:   []string{"o"},
+		KeysFeedOrder:     []string{"O"},
+		KeysNightMode:     []string{"n"},
+		KeysRecheckLogin:  []string{"r"},
+		KeysRetry:         []string{"r"},
 
 		KeysShareOpen:  []string{"s"},
 		KeysShareClose: []string{"S"},
 
 		KeysCommentsOpen:  []string{"c"},
 		KeysCommentsClose: []string{"C"},
+		KeysLikeComment:   []string{"L"},
+		KeysCommentSort:   []string{"z"},
 
 		KeysHelpOpen:  []string{"?"},
 		KeysHelpClose: []string{"?"},
@@ -250,6 +541,8 @@ func defaultSettings() Settings {
 
 	if goruntime.GOOS == "darwin" {
 		s.RetinaScale = 2
+		s.FallbackCellW = 16
+		s.FallbackCellH = 32
 	}
 	return s
 }
@@ -279,11 +572,71 @@ func LoadSettings(configDir string) {
 	if vals, ok := conf["show_navbar"]; ok {
 		s.ShowNavbar = (vals[len(vals)-1] == "true")
 	}
+	if vals, ok := conf["headed"]; ok {
+		s.Headed = (vals[len(vals)-1] == "true")
+	}
+	if vals, ok := conf["resume"]; ok {
+		s.Resume = (vals[len(vals)-1] == "true")
+	}
+	if vals, ok := conf["navbar_compact"]; ok {
+		s.NavbarCompact = (vals[len(vals)-1] == "true")
+	}
+	if vals, ok := conf["show_counts"]; ok {
+		s.ShowCounts = (vals[len(vals)-1] == "true")
+	}
+	if vals, ok := conf["glyph_like"]; ok && vals[len(vals)-1] != "" {
+		s.GlyphLike = vals[len(vals)-1]
+	}
+	if vals, ok := conf["glyph_liked"]; ok && vals[len(vals)-1] != "" {
+		s.GlyphLiked = vals[len(vals)-1]
+	}
+	if vals, ok := conf["glyph_comment"]; ok && vals[len(vals)-1] != "" {
+		s.GlyphComment = vals[len(vals)-1]
+	}
+	if vals, ok := conf["glyph_verified"]; ok && vals[len(vals)-1] != "" {
+		s.GlyphVerified = vals[len(vals)-1]
+	}
+	if vals, ok := conf["glyph_explicit"]; ok && vals[len(vals)-1] != "" {
+		s.GlyphExplicit = vals[len(vals)-1]
+	}
+	if vals, ok := conf["glyph_heart"]; ok && vals[len(vals)-1] != "" {
+		s.GlyphHeart = vals[len(vals)-1]
+	}
+	if vals, ok := conf["glyph_repost"]; ok && vals[len(vals)-1] != "" {
+		s.GlyphRepost = vals[len(vals)-1]
+	}
+	if vals, ok := conf["glyph_saved"]; ok && vals[len(vals)-1] != "" {
+		s.GlyphSaved = vals[len(vals)-1]
+	}
+	if vals, ok := conf["glyph_unsaved"]; ok && vals[len(vals)-1] != "" {
+		s.GlyphUnsaved = vals[len(vals)-1]
+	}
+	if vals, ok := conf["resume_position"]; ok {
+		s.ResumePosition = (vals[len(vals)-1] == "true")
+	}
+	if vals, ok := conf["hide_explicit"]; ok {
+		s.HideExplicit = (vals[len(vals)-1] == "true")
+	}
+	if vals, ok := conf["jump_size"]; ok {
+		if n, err := strconv.Atoi(vals[len(vals)-1]); err == nil && n > 0 {
+			s.JumpSize = n
+		}
+	}
 	if vals, ok := conf["retina_scale"]; ok {
 		if n, err := strconv.Atoi(vals[len(vals)-1]); err == nil {
 			s.RetinaScale = n
 		}
 	}
+	if vals, ok := conf["fallback_cell_width"]; ok {
+		if n, err := strconv.Atoi(vals[len(vals)-1]); err == nil && n > 0 {
+			s.FallbackCellW = n
+		}
+	}
+	if vals, ok := conf["fallback_cell_height"]; ok {
+		if n, err := strconv.Atoi(vals[len(vals)-1]); err == nil && n > 0 {
+			s.FallbackCellH = n
+		}
+	}
 	if vals, ok := conf["reel_width"]; ok {
 		if n, err := strconv.Atoi(vals[len(vals)-1]); err == nil {
 			s.ReelWidth = n
@@ -299,43 +652,248 @@ func LoadSettings(configDir string) {
 			s.ReelSizeStep = n
 		}
 	}
+	if vals, ok := conf["fit_mode"]; ok {
+		switch v := vals[len(vals)-1]; v {
+		case "contain", "cover", "stretch":
+			s.FitMode = v
+		}
+	}
+	if vals, ok := conf["video_offset_rows"]; ok {
+		if n, err := strconv.Atoi(vals[len(vals)-1]); err == nil {
+			s.VideoOffsetRows = n
+		}
+	}
+	if vals, ok := conf["video_offset_cols"]; ok {
+		if n, err := strconv.Atoi(vals[len(vals)-1]); err == nil {
+			s.VideoOffsetCols = n
+		}
+	}
 	if vals, ok := conf["volume"]; ok {
 		if n, err := strconv.ParseFloat(vals[len(vals)-1], 64); err == nil {
 			s.Volume = n
 		}
 	}
-	if vals, ok := conf["gif_cell_height"]; ok {
-		if n, err := strconv.Atoi(vals[len(vals)-1]); err == nil {
-			s.GifCellHeight = n
+	if vals, ok := conf["muted"]; ok {
+		s.Muted = (vals[len(vals)-1] == "true")
+	}
+	if vals, ok := conf["brightness"]; ok {
+		if n, err := strconv.ParseFloat(vals[len(vals)-1], 64); err == nil {
+			s.Brightness = min(max(n, 0.1), 2)
 		}
 	}
+	// comment_gif_height is accepted as an alias; writeConf saves gif_cell_height
+	for _, key := range []string{"gif_cell_height", "comment_gif_height"} {
+		if vals, ok := conf[key]; ok {
+			if n, err := strconv.Atoi(vals[len(vals)-1]); err == nil && n > 0 {
+				s.GifCellHeight = n
+			}
+		}
+	}
+	if vals, ok := conf["comment_indent"]; ok {
+		if n, err := strconv.Atoi(vals[len(vals)-1]); err == nil && n >= 0 {
+			s.CommentIndent = n
+		}
+	}
+	if vals, ok := conf["comment_separator"]; ok {
+		switch v := vals[len(vals)-1]; v {
+		case "none", "blank", "line":
+			s.CommentSeparator = v
+		}
+	}
+	if vals, ok := conf["highlight_hashtags"]; ok {
+		s.HighlightHashtags = (vals[len(vals)-1] == "true")
+	}
 	if vals, ok := conf["panel_shrink_steps"]; ok {
 		if n, err := strconv.Atoi(vals[len(vals)-1]); err == nil {
 			s.PanelShrinkSteps = n
 		}
 	}
+	if vals, ok := conf["audio_fade_ms"]; ok {
+		if n, err := strconv.Atoi(vals[len(vals)-1]); err == nil {
+			s.AudioFadeMs = n
+		}
+	}
+	if vals, ok := conf["normalize_audio"]; ok {
+		s.NormalizeAudio = (vals[len(vals)-1] == "true")
+	}
+	if vals, ok := conf["audio_buffer_ms"]; ok {
+		if n, err := strconv.Atoi(vals[len(vals)-1]); err == nil && n > 0 {
+			s.AudioBufferMs = n
+		}
+	}
+	if vals, ok := conf["frame_buffer"]; ok {
+		if n, err := strconv.Atoi(vals[len(vals)-1]); err == nil && n > 0 {
+			s.FrameBuffer = n
+		}
+	}
+	if vals, ok := conf["sync_threshold_ms"]; ok {
+		if n, err := strconv.Atoi(vals[len(vals)-1]); err == nil && n > 0 {
+			s.SyncThresholdMs = n
+		}
+	}
+	if vals, ok := conf["sync_mode"]; ok {
+		switch v := vals[len(vals)-1]; v {
+		case "drop", "sleep", "adaptive":
+			s.SyncMode = v
+		}
+	}
+	if vals, ok := conf["download_dir"]; ok {
+		if v := vals[len(vals)-1]; v != "" {
+			s.DownloadDir = v
+		}
+	}
+	if vals, ok := conf["export_pfp"]; ok {
+		s.ExportPfp = (vals[len(vals)-1] == "true")
+	}
+	if vals, ok := conf["cache_size"]; ok {
+		if n, err := strconv.Atoi(vals[len(vals)-1]); err == nil && n > 0 {
+			s.CacheSize = n
+		}
+	}
+	if vals, ok := conf["sync_update"]; ok {
+		switch v := vals[len(vals)-1]; v {
+		case "auto", "on", "off":
+			s.SyncUpdate = v
+		}
+	}
+	if vals, ok := conf["pfp_position"]; ok {
+		switch v := vals[len(vals)-1]; v {
+		case "bottomleft", "topleft", "topright", "bottomright":
+			s.PfpPosition = v
+		}
+	}
+	if vals, ok := conf["show_profile_pic"]; ok {
+		s.ShowProfilePic = (vals[len(vals)-1] == "true")
+	}
+	// checked against the player's renderers when the TUI sets it, which
+	// logs an unknown name and falls back to auto
+	if vals, ok := conf["renderer"]; ok && vals[len(vals)-1] != "" {
+		s.Renderer = vals[len(vals)-1]
+	}
+	if vals, ok := conf["night_brightness"]; ok {
+		if n, err := strconv.ParseFloat(vals[len(vals)-1], 64); err == nil {
+			s.NightBrightness = min(max(n, 0), 1)
+		}
+	}
+	if vals, ok := conf["prewarm"]; ok {
+		s.Prewarm = (vals[len(vals)-1] == "true")
+	}
+	if vals, ok := conf["hold_frame"]; ok {
+		s.HoldFrame = (vals[len(vals)-1] == "true")
+	}
+	if vals, ok := conf["animated_pfp"]; ok {
+		s.AnimatedPfp = (vals[len(vals)-1] == "true")
+	}
+	if vals, ok := conf["audio_meter"]; ok {
+		s.AudioMeter = (vals[len(vals)-1] == "true")
+	}
+	if vals, ok := conf["like_animation"]; ok {
+		s.LikeAnimation = (vals[len(vals)-1] == "true")
+	}
+	if vals, ok := conf["cache_policy"]; ok {
+		switch v := vals[len(vals)-1]; v {
+		case "fifo", "lru":
+			s.CachePolicy = v
+		}
+	}
+	if vals, ok := conf["download_strategy"]; ok {
+		switch v := vals[len(vals)-1]; v {
+		case "auto", "http", "headers", "cdp":
+			s.DownloadStrategy = v
+		}
+	}
+	if vals, ok := conf["video_quality"]; ok {
+		switch v := vals[len(vals)-1]; v {
+		case "low", "high":
+			s.VideoQuality = v
+		}
+	}
+	if vals, ok := conf["max_concurrent_downloads"]; ok {
+		if n, err := strconv.Atoi(vals[len(vals)-1]); err == nil && n > 0 {
+			s.MaxDownloads = n
+		}
+	}
+	if vals, ok := conf["sync_max_retries"]; ok {
+		if n, err := strconv.Atoi(vals[len(vals)-1]); err == nil && n > 0 {
+			s.SyncMaxRetries = n
+		}
+	}
+	if vals, ok := conf["sync_timeout_ms"]; ok {
+		if n, err := strconv.Atoi(vals[len(vals)-1]); err == nil {
+			s.SyncTimeoutMs = n
+		}
+	}
+	if vals, ok := conf["comments_timeout_ms"]; ok {
+		if n, err := strconv.Atoi(vals[len(vals)-1]); err == nil {
+			s.CommentsTimeoutMs = n
+		}
+	}
+	if vals, ok := conf["autoplay_dwell_seconds"]; ok {
+		if n, err := strconv.Atoi(vals[len(vals)-1]); err == nil && n >= 0 {
+			s.AutoplayDwell = n
+		}
+	}
+	if vals, ok := conf["loop_count"]; ok {
+		if n, err := strconv.Atoi(vals[len(vals)-1]); err == nil && n >= 0 {
+			s.LoopCount = n
+		}
+	}
+	if vals, ok := conf["page_load_timeout_ms"]; ok {
+		if n, err := strconv.Atoi(vals[len(vals)-1]); err == nil {
+			s.PageLoadTimeoutMs = n
+		}
+	}
+	if vals, ok := conf["resize_debounce_ms"]; ok {
+		if n, err := strconv.Atoi(vals[len(vals)-1]); err == nil && n >= 0 {
+			s.ResizeDebounceMs = n
+		}
+	}
 
 	loadKey(conf, "key_next", &s.KeysNext)
 	loadKey(conf, "key_previous", &s.KeysPrevious)
+	loadKey(conf, "key_reel_next", &s.KeysReelNext)
+	loadKey(conf, "key_reel_previous", &s.KeysReelPrevious)
+	loadKey(conf, "key_jump_forward", &s.KeysJumpForward)
+	loadKey(conf, "key_jump_back", &s.KeysJumpBack)
 	loadKey(conf, "key_pause", &s.KeysPause)
 	loadKey(conf, "key_mute", &s.KeysMute)
 	loadKey(conf, "key_like", &s.KeysLike)
 	loadKey(conf, "key_repost", &s.KeysRepost)
 	loadKey(conf, "key_navbar", &s.KeysNavbar)
+	loadKey(conf, "key_navbar_compact", &s.KeysNavbarCompact)
 	loadKey(conf, "key_vol_up", &s.KeysVolUp)
 	loadKey(conf, "key_vol_down", &s.KeysVolDown)
+	loadKey(conf, "key_brightness_up", &s.KeysBrightUp)
+	loadKey(conf, "key_brightness_down", &s.KeysBrightDown)
 	loadKey(conf, "key_reel_size_inc", &s.KeysReelSizeInc)
 	loadKey(conf, "key_reel_size_dec", &s.KeysReelSizeDec)
 	loadKey(conf, "key_quit", &s.KeysQuit)
 	loadKey(conf, "key_copy_link", &s.KeysCopyLink)
+	loadKey(conf, "key_open_browser", &s.KeysOpenBrowser)
+	loadKey(conf, "key_copy_comments", &s.KeysCopyComments)
+	loadKey(conf, "key_copy_text", &s.KeysCopyText)
+	loadKey(conf, "key_refresh_reel", &s.KeysRefreshReel)
 	loadKey(conf, "key_save", &s.KeysSave)
+	loadKey(conf, "key_export", &s.KeysExport)
 	loadKey(conf, "key_seek_forward", &s.KeysSeekForward)
 	loadKey(conf, "key_seek_backward", &s.KeysSeekBackward)
+	loadKey(conf, "key_frame_forward", &s.KeysFrameForward)
 	loadKey(conf, "key_select", &s.KeysSelect)
+	loadKey(conf, "key_scroll_caption", &s.KeysScrollCaption)
+	loadKey(conf, "key_focus", &s.KeysFocus)
+	loadKey(conf, "key_grid", &s.KeysGrid)
+	loadKey(conf, "key_autoplay", &s.KeysAutoplay)
+	loadKey(conf, "key_filter_music", &s.KeysFilterMusic)
+	loadKey(conf, "key_feed_order", &s.KeysFeedOrder)
+	loadKey(conf, "key_nightmode", &s.KeysNightMode)
+	loadKey(conf, "key_recheck_login", &s.KeysRecheckLogin)
+	loadKey(conf, "key_retry", &s.KeysRetry)
 	loadKey(conf, "key_share_open", &s.KeysShareOpen)
 	loadKey(conf, "key_share_close", &s.KeysShareClose)
 	loadKey(conf, "key_comments_open", &s.KeysCommentsOpen)
 	loadKey(conf, "key_comments_close", &s.KeysCommentsClose)
+	loadKey(conf, "key_like_comment", &s.KeysLikeComment)
+	loadKey(conf, "key_comment_sort", &s.KeysCommentSort)
 	loadKey(conf, "key_help_open", &s.KeysHelpOpen)
 	loadKey(conf, "key_help_close", &s.KeysHelpClose)
 	loadKey(conf, "key_friends_open", &s.KeysChatsOpen)
@@ -359,39 +917,169 @@ func writeConf(path string, s Settings) error {
 
 	var b strings.Builder
 	b.WriteString("# insta reels TUI config\n\n")
+	b.WriteString("# always run the browser headed (visible), as if --headed were passed\n")
+	b.WriteString(fmt.Sprintf("headed = %t\n", s.Headed))
+	b.WriteString("# reopen the reel you quit on at the next launch\n")
+	b.WriteString(fmt.Sprintf("resume = %t\n", s.Resume))
 	b.WriteString(fmt.Sprintf("show_navbar = %t\n", s.ShowNavbar))
+	b.WriteString(fmt.Sprintf("navbar_compact = %t\n", s.NavbarCompact))
+	b.WriteString(fmt.Sprintf("show_counts = %t\n", s.ShowCounts))
+	b.WriteString("# icons, for terminals/fonts that can't draw the defaults (ascii: <3, *, c, v, E, <3, rp, S, s)\n")
+	b.WriteString(fmt.Sprintf("glyph_like = %s\n", s.GlyphLike))
+	b.WriteString(fmt.Sprintf("glyph_liked = %s\n", s.GlyphLiked))
+	b.WriteString(fmt.Sprintf("glyph_comment = %s\n", s.GlyphComment))
+	b.WriteString(fmt.Sprintf("glyph_verified = %s\n", s.GlyphVerified))
+	b.WriteString(fmt.Sprintf("glyph_explicit = %s\n", s.GlyphExplicit))
+	b.WriteString(fmt.Sprintf("glyph_heart = %s\n", s.GlyphHeart))
+	b.WriteString(fmt.Sprintf("glyph_repost = %s\n", s.GlyphRepost))
+	b.WriteString(fmt.Sprintf("glyph_saved = %s\n", s.GlyphSaved))
+	b.WriteString(fmt.Sprintf("glyph_unsaved = %s\n", s.GlyphUnsaved))
+	b.WriteString("# resume a revisited reel where you left it instead of from the start\n")
+	b.WriteString(fmt.Sprintf("resume_position = %t\n", s.ResumePosition))
+	b.WriteString("# skip reels with explicit music when navigating\n")
+	b.WriteString(fmt.Sprintf("hide_explicit = %t\n", s.HideExplicit))
+	b.WriteString("# reels key_jump_forward and key_jump_back move at once\n")
+	b.WriteString(fmt.Sprintf("jump_size = %d\n", s.JumpSize))
+	b.WriteString("# corner of the video the creator's profile pic sits in: bottomleft, topleft, topright, bottomright\n")
+	b.WriteString(fmt.Sprintf("pfp_position = %s\n", s.PfpPosition))
+	b.WriteString("# draw the creator's profile pic by the video; false also skips downloading it\n")
+	b.WriteString(fmt.Sprintf("show_profile_pic = %t\n", s.ShowProfilePic))
 	b.WriteString(fmt.Sprintf("retina_scale = %d\n", s.RetinaScale))
+	b.WriteString("# cell size in pixels to assume when the terminal doesn't report a believable one\n")
+	b.WriteString(fmt.Sprintf("fallback_cell_width = %d\n", s.FallbackCellW))
+	b.WriteString(fmt.Sprintf("fallback_cell_height = %d\n", s.FallbackCellH))
+	b.WriteString("# synchronized-update escapes around frames: auto (probe the terminal), on, off\n")
+	b.WriteString(fmt.Sprintf("sync_update = %s\n", s.SyncUpdate))
+	b.WriteString("# graphics renderer: auto (detect), kitty, sixel, halfblock\n")
+	b.WriteString(fmt.Sprintf("renderer = %s\n", s.Renderer))
 	b.WriteString("\n")
 	b.WriteString("# reels will be scales within this bounding box\n")
 	b.WriteString(fmt.Sprintf("reel_width = %d\n", s.ReelWidth))
 	b.WriteString(fmt.Sprintf("reel_height = %d\n", s.ReelHeight))
 	b.WriteString(fmt.Sprintf("reel_size_step = %d\n", s.ReelSizeStep))
+	b.WriteString("# how videos that aren't 9:16 fill the box: contain (letterbox), cover (crop to\n")
+	b.WriteString("# fill) or stretch (fill, ignoring aspect ratio)\n")
+	b.WriteString(fmt.Sprintf("fit_mode = %s\n", s.FitMode))
+	b.WriteString("# shift the centered video by this many rows/cols (negative = up/left)\n")
+	b.WriteString(fmt.Sprintf("video_offset_rows = %d\n", s.VideoOffsetRows))
+	b.WriteString(fmt.Sprintf("video_offset_cols = %d\n", s.VideoOffsetCols))
 	b.WriteString(fmt.Sprintf("volume = %g\n", s.Volume))
+	b.WriteString(fmt.Sprintf("muted = %t\n", s.Muted))
+	b.WriteString("# video brightness gain (0.1-2, 1 = unchanged), set with key_brightness_up/down\n")
+	b.WriteString(fmt.Sprintf("brightness = %g\n", s.Brightness))
+	b.WriteString("# terminal rows a comment gif takes up\n")
 	b.WriteString(fmt.Sprintf("gif_cell_height = %d\n", s.GifCellHeight))
+	b.WriteString("# columns comment text is indented under its username (replies get twice this)\n")
+	b.WriteString(fmt.Sprintf("comment_indent = %d\n", s.CommentIndent))
+	b.WriteString("# between comment threads: none, blank (empty line) or line (a thin rule)\n")
+	b.WriteString(fmt.Sprintf("comment_separator = %s\n", s.CommentSeparator))
+	b.WriteString("# move a caption's #hashtags and @mentions onto their own highlighted line below it\n")
+	b.WriteString(fmt.Sprintf("highlight_hashtags = %t\n", s.HighlightHashtags))
 	b.WriteString(fmt.Sprintf("panel_shrink = %d\n", s.PanelShrinkSteps))
 	b.WriteString("\n")
+	b.WriteString("# keep audio playing and fade it out over this many ms when switching reels (0 = cut immediately)\n")
+	b.WriteString(fmt.Sprintf("audio_fade_ms = %d\n", s.AudioFadeMs))
+	b.WriteString("# level each reel's loudness so quiet and loud reels play at about the same volume\n")
+	b.WriteString(fmt.Sprintf("normalize_audio = %t\n", s.NormalizeAudio))
+	b.WriteString("# speaker buffer: raise if audio crackles, lower to reduce audio lag (applies on restart)\n")
+	b.WriteString(fmt.Sprintf("audio_buffer_ms = %d\n", s.AudioBufferMs))
+	b.WriteString("# decoded frames queued ahead of rendering (2 = double, 3 = triple buffering)\n")
+	b.WriteString(fmt.Sprintf("frame_buffer = %d\n", s.FrameBuffer))
+	b.WriteString("# how far (ms) video may drift from the audio before it's corrected\n")
+	b.WriteString(fmt.Sprintf("sync_threshold_ms = %d\n", s.SyncThresholdMs))
+	b.WriteString("# drop: skip late frames; sleep: wait out early frames and draw late ones anyway;\n")
+	b.WriteString("# adaptive: like drop, allowing for how long the terminal takes to draw a frame\n")
+	b.WriteString(fmt.Sprintf("sync_mode = %s\n", s.SyncMode))
+	b.WriteString("\n")
+	b.WriteString("# video brightness (0-1) while night mode is on\n")
+	b.WriteString(fmt.Sprintf("night_brightness = %g\n", s.NightBrightness))
+	b.WriteString("# open the next reel's demuxer/decoder during prefetch so it starts faster\n")
+	b.WriteString(fmt.Sprintf("prewarm = %t\n", s.Prewarm))
+	b.WriteString("# keep the last frame up until the next one is drawn, so switching reels doesn't flash blank\n")
+	b.WriteString(fmt.Sprintf("hold_frame = %t\n", s.HoldFrame))
+	b.WriteString("# loop animated profile pictures (adds some per-frame render work)\n")
+	b.WriteString(fmt.Sprintf("animated_pfp = %t\n", s.AnimatedPfp))
+	b.WriteString("# show a small peak audio meter in the status line, even when muted\n")
+	b.WriteString(fmt.Sprintf("audio_meter = %t\n", s.AudioMeter))
+	b.WriteString("# play a short heart burst above the video when liking a reel\n")
+	b.WriteString(fmt.Sprintf("like_animation = %t\n", s.LikeAnimation))
+	b.WriteString("# cache eviction: fifo (oldest download first) or lru (least recently watched first)\n")
+	b.WriteString(fmt.Sprintf("cache_policy = %s\n", s.CachePolicy))
+	b.WriteString("# number of reels whose video and profile pictures are kept in the cache\n")
+	b.WriteString(fmt.Sprintf("cache_size = %d\n", s.CacheSize))
+	b.WriteString("# where key_export saves reels as <username>_<code>.mp4 (~ = home directory)\n")
+	b.WriteString(fmt.Sprintf("download_dir = %s\n", s.DownloadDir))
+	b.WriteString("# also save the creator's profile picture as <username>_<code>.jpg\n")
+	b.WriteString(fmt.Sprintf("export_pfp = %t\n", s.ExportPfp))
+	b.WriteString("# how videos are downloaded: auto (try each in turn), http, headers (adds\n")
+	b.WriteString("# instagram referer/user agent) or cdp (fetched by the browser page)\n")
+	b.WriteString(fmt.Sprintf("download_strategy = %s\n", s.DownloadStrategy))
+	b.WriteString("# which encoding of each reel to download: low (smallest, saves bandwidth) or high (full\n")
+	b.WriteString("# resolution). Applies to reels not downloaded yet\n")
+	b.WriteString(fmt.Sprintf("video_quality = %s\n", s.VideoQuality))
+	b.WriteString("# video, pfp and gif downloads allowed in flight at once (applies on restart)\n")
+	b.WriteString(fmt.Sprintf("max_concurrent_downloads = %d\n", s.MaxDownloads))
+	b.WriteString("\n")
+	b.WriteString("# scroll attempts and overall time limit when syncing the browser to a reel (0 ms = no limit)\n")
+	b.WriteString(fmt.Sprintf("sync_max_retries = %d\n", s.SyncMaxRetries))
+	b.WriteString(fmt.Sprintf("sync_timeout_ms = %d\n", s.SyncTimeoutMs))
+	b.WriteString("# how long to wait for comments before offering a retry (0 = wait forever)\n")
+	b.WriteString(fmt.Sprintf("comments_timeout_ms = %d\n", s.CommentsTimeoutMs))
+	b.WriteString("# key_autoplay moves on after this many seconds (0 = once the reel has played through)\n")
+	b.WriteString(fmt.Sprintf("autoplay_dwell_seconds = %d\n", s.AutoplayDwell))
+	b.WriteString("# play each reel this many times, then move on to the next one (0 = loop until you move on)\n")
+	b.WriteString(fmt.Sprintf("loop_count = %d\n", s.LoopCount))
+	b.WriteString("# longest to wait for instagram pages to render on startup (0 = wait forever)\n")
+	b.WriteString(fmt.Sprintf("page_load_timeout_ms = %d\n", s.PageLoadTimeoutMs))
+	b.WriteString("# wait for the terminal to stop resizing this long before re-laying out the video (0 = immediately)\n")
+	b.WriteString(fmt.Sprintf("resize_debounce_ms = %d\n", s.ResizeDebounceMs))
+	b.WriteString("\n")
 	b.WriteString("# configurable keybinds\n")
 	writeKeys(&b, "key_next", s.KeysNext)
 	writeKeys(&b, "key_previous", s.KeysPrevious)
+	writeKeys(&b, "key_reel_next", s.KeysReelNext)
+	writeKeys(&b, "key_reel_previous", s.KeysReelPrevious)
+	writeKeys(&b, "key_jump_forward", s.KeysJumpForward)
+	writeKeys(&b, "key_jump_back", s.KeysJumpBack)
 	writeKeys(&b, "key_pause", s.KeysPause)
 	writeKeys(&b, "key_mute", s.KeysMute)
 	writeKeys(&b, "key_like", s.KeysLike)
 	writeKeys(&b, "key_repost", s.KeysRepost)
 	writeKeys(&b, "key_navbar", s.KeysNavbar)
+	writeKeys(&b, "key_navbar_compact", s.KeysNavbarCompact)
 	writeKeys(&b, "key_vol_up", s.KeysVolUp)
 	writeKeys(&b, "key_vol_down", s.KeysVolDown)
+	writeKeys(&b, "key_brightness_up", s.KeysBrightUp)
+	writeKeys(&b, "key_brightness_down", s.KeysBrightDown)
 	writeKeys(&b, "key_reel_size_inc", s.KeysReelSizeInc)
 	writeKeys(&b, "key_reel_size_dec", s.KeysReelSizeDec)
 	writeKeys(&b, "key_copy_link", s.KeysCopyLink)
+	writeKeys(&b, "key_open_browser", s.KeysOpenBrowser)
+	writeKeys(&b, "key_copy_comments", s.KeysCopyComments)
+	writeKeys(&b, "key_copy_text", s.KeysCopyText)
+	writeKeys(&b, "key_refresh_reel", s.KeysRefreshReel)
 	writeKeys(&b, "key_save", s.KeysSave)
+	writeKeys(&b, "key_export", s.KeysExport)
 	writeKeys(&b, "key_quit", s.KeysQuit)
 	writeKeys(&b, "key_seek_forward", s.KeysSeekForward)
 	writeKeys(&b, "key_seek_backward", s.KeysSeekBackward)
+	writeKeys(&b, "key_frame_forward", s.KeysFrameForward)
 	writeKeys(&b, "key_select", s.KeysSelect)
+	writeKeys(&b, "key_scroll_caption", s.KeysScrollCaption)
+	writeKeys(&b, "key_focus", s.KeysFocus)
+	writeKeys(&b, "key_grid", s.KeysGrid)
+	writeKeys(&b, "key_autoplay", s.KeysAutoplay)
+	writeKeys(&b, "key_filter_music", s.KeysFilterMusic)
+	writeKeys(&b, "key_feed_order", s.KeysFeedOrder)
+	writeKeys(&b, "key_nightmode", s.KeysNightMode)
+	writeKeys(&b, "key_recheck_login", s.KeysRecheckLogin)
+	writeKeys(&b, "key_retry", s.KeysRetry)
 	writeKeys(&b, "key_share_open", s.KeysShareOpen)
 	writeKeys(&b, "key_share_close", s.KeysShareClose)
 	writeKeys(&b, "key_comments_open", s.KeysCommentsOpen)
 	writeKeys(&b, "key_comments_close", s.KeysCommentsClose)
+	writeKeys(&b, "key_like_comment", s.KeysLikeComment)
+	writeKeys(&b, "key_comment_sort", s.KeysCommentSort)
 	writeKeys(&b, "key_help_open", s.KeysHelpOpen)
 	writeKeys(&b, "key_help_close", s.KeysHelpClose)
 	writeKeys(&b, "key_friends_open", s.KeysChatsOpen)
@@ -424,6 +1112,46 @@ func parseConf(path string) map[string][]string {
 	return result
 }
 
+// SaveResumeState records code as the reel to reopen on the next launch (see
+// the resume setting). An empty code clears it.
+func (b *ChromeBackend) SaveResumeState(code string) error {
+	path := filepath.Join(b.configDir, "resume")
+	if code == "" {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+	return os.WriteFile(path, []byte(code+"\n"), 0644)
+}
+
+// LoadResumeState returns the reel code SaveResumeState recorded, or "" if
+// there is none.
+func (b *ChromeBackend) LoadResumeState() string {
+	data, err := os.ReadFile(filepath.Join(b.configDir, "resume"))
+	if err != nil {
+		return ""
+	}
+	code, err := ParseReelCode(strings.TrimSpace(string(data)))
+	if err != nil {
+		return ""
+	}
+	return code
+}
+
+// saveConf writes s to reels.conf. The first failed write emits
+// EventConfigNotSaved so the user knows settings aren't persisting; later
+// failures are only logged.
+func (b *ChromeBackend) saveConf(s Settings) {
+	path := filepath.Join(b.configDir, "reels.conf")
+	if err := writeConf(path, s); err != nil {
+		log.Printf("write config: %v", err)
+		if b.confWarned.CompareAndSwap(false, true) {
+			b.events <- Event{Type: EventConfigNotSaved}
+		}
+	}
+}
+
 // SetReelSize updates the reel bounding box dimensions and persists to disk.
 func (b *ChromeBackend) SetReelSize(width, height int) error {
 	settingsMu.Lock()
@@ -432,8 +1160,7 @@ func (b *ChromeBackend) SetReelSize(width, height int) error {
 	snapshot := Config
 	settingsMu.Unlock()
 
-	path := filepath.Join(b.configDir, "reels.conf")
-	go writeConf(path, snapshot)
+	go b.saveConf(snapshot)
 	return nil
 }
 
@@ -445,11 +1172,23 @@ func (b *ChromeBackend) ToggleNavbar() bool {
 	snapshot := Config
 	settingsMu.Unlock()
 
-	path := filepath.Join(b.configDir, "reels.conf")
-	go writeConf(path, snapshot)
+	go b.saveConf(snapshot)
 	return showNavbar
 }
 
+// ToggleNavbarCompact switches the navbar between the full hints and a
+// one-line legend, persists to disk, and returns true if compact
+func (b *ChromeBackend) ToggleNavbarCompact() bool {
+	settingsMu.Lock()
+	Config.NavbarCompact = !Config.NavbarCompact
+	compact := Config.NavbarCompact
+	snapshot := Config
+	settingsMu.Unlock()
+
+	go b.saveConf(snapshot)
+	return compact
+}
+
 // SetVolume updates volume and persists to disk
 func (b *ChromeBackend) SetVolume(vol float64) error {
 	settingsMu.Lock()
@@ -457,8 +1196,47 @@ func (b *ChromeBackend) SetVolume(vol float64) error {
 	snapshot := Config
 	settingsMu.Unlock()
 
-	path := filepath.Join(b.configDir, "reels.conf")
-	go writeConf(path, snapshot)
+	go b.saveConf(snapshot)
+	return nil
+}
+
+// SetBrightness updates the video brightness gain and persists to disk
+func (b *ChromeBackend) SetBrightness(brightness float64) error {
+	settingsMu.Lock()
+	Config.Brightness = brightness
+	snapshot := Config
+	settingsMu.Unlock()
+
+	go b.saveConf(snapshot)
+	return nil
+}
+
+// SetCacheSize changes how many reels stay in the video and reel pfp caches
+// and persists it, evicting the oldest at once if they now hold more.
+func (b *ChromeBackend) SetCacheSize(size int) error {
+	if size < 1 {
+		return fmt.Errorf("cache size must be at least 1, got %d", size)
+	}
+	settingsMu.Lock()
+	Config.CacheSize = size
+	snapshot := Config
+	settingsMu.Unlock()
+
+	videoCache.resize(size)
+	reelPfpCache.resize(size)
+
+	go b.saveConf(snapshot)
+	return nil
+}
+
+// SetMuted updates the mute state and persists to disk
+func (b *ChromeBackend) SetMuted(muted bool) error {
+	settingsMu.Lock()
+	Config.Muted = muted
+	snapshot := Config
+	settingsMu.Unlock()
+
+	go b.saveConf(snapshot)
 	return nil
 }
 
@@ -481,6 +1259,7 @@ func fetchURLsHTTP(urls []string) [][]byte {
 		wg.Add(1)
 		go func(i int, u string) {
 			defer wg.Done()
+			defer acquireDownload()()
 			resp, err := gifHTTPClient.Get(u)
 			if err != nil {
 				return
@@ -564,9 +1343,102 @@ func (b *ChromeBackend) fetchURLsJS(urls []string) [][]byte {
 }
 */
 
+// touchReelFiles marks a cached reel's video and pfps as just used, so the
+// lru cache policy keeps reels the user keeps coming back to.
+func touchReelFiles(videoFile, pfpFile string, floating []FloatingPfpFile) {
+	videoCache.touch(videoFile)
+	reelPfpCache.touch(pfpFile)
+	for _, f := range floating {
+		if f.Path != "" {
+			reelPfpCache.touch(f.Path)
+		}
+	}
+}
+
+// videoFile returns the cache path of the video for the reel at index.
+func (b *ChromeBackend) videoFile(index int, code string) string {
+	return filepath.Join(b.cacheDir, fmt.Sprintf("%03d_%s.mp4", index, code))
+}
+
+// pinVideo keeps the video of the reel at index, the one about to play, from
+// being evicted while it plays.
+func (b *ChromeBackend) pinVideo(index int) {
+	pk := b.activeCursor().PKAt(index)
+	if pk == "" {
+		return
+	}
+	b.reelsMu.RLock()
+	r, ok := b.reels[pk]
+	b.reelsMu.RUnlock()
+	if ok {
+		videoCache.pin(b.videoFile(index, r.Code))
+	}
+}
+
+// CachedVideo returns the cached video path for the reel at index, or "".
+func (b *ChromeBackend) CachedVideo(index int) string {
+	pk := b.activeCursor().PKAt(index)
+	if pk == "" {
+		return ""
+	}
+	b.reelsMu.RLock()
+	r, ok := b.reels[pk]
+	b.reelsMu.RUnlock()
+	if !ok {
+		return ""
+	}
+
+	videoFile := b.videoFile(index, r.Code)
+	if !videoCache.has(videoFile) {
+		return ""
+	}
+	return videoFile
+}
+
+// IsCached reports whether the reel at index has its video in the cache.
+func (b *ChromeBackend) IsCached(index int) bool {
+	return b.CachedVideo(index) != ""
+}
+
+// ErrDiskFull is returned by Download when the video can't be written to the
+// cache even after evicting older videos to make room.
+var ErrDiskFull = errors.New("disk full: free up space on the cache drive")
+
+// writeFile is os.WriteFile, swapped out by tests to simulate a full disk.
+var writeFile = os.WriteFile
+
+// writeVideo writes a downloaded video into the cache. If the disk is full it
+// evicts the older half of the video cache and tries once more.
+func writeVideo(path string, data []byte) error {
+	err := writeFile(path, data, 0644)
+	if !errors.Is(err, syscall.ENOSPC) {
+		return err
+	}
+	os.Remove(path) // don't leave a truncated video behind
+
+	evicted := videoCache.evictOldest(max(videoCache.capacity()/2, 1))
+	log.Printf("cache: disk full writing %s, evicted %d videos", filepath.Base(path), evicted)
+	if evicted == 0 {
+		return ErrDiskFull
+	}
+
+	if err := writeFile(path, data, 0644); err != nil {
+		os.Remove(path)
+		if errors.Is(err, syscall.ENOSPC) {
+			return ErrDiskFull
+		}
+		return err
+	}
+	return nil
+}
+
 // Download downloads a reel video and profile picture to the cache directory
 func (b *ChromeBackend) Download(index int) (string, string, []FloatingPfpFile, error) {
-	pk := b.activeCursor().PKAt(index)
+	cur := b.activeCursor()
+	if fc, ok := cur.(*FeedCursor); ok {
+		fc.markDownloaded(index)
+	}
+	pk := cur.PKAt(index)
 	if pk == "" {
 		return "", "", nil, fmt.Errorf("index out of range")
 	}
@@ -579,11 +1451,12 @@ func (b *ChromeBackend) Download(index int) (string, string, []FloatingPfpFile,
 	reel := *r
 	b.reelsMu.RUnlock()
 
-	if reel.VideoURL == "" {
+	videoURL := reel.videoURLFor(GetSettings().VideoQuality)
+	if videoURL == "" {
 		return "", "", nil, fmt.Errorf("no video URL")
 	}
 
-	videoFile := filepath.Join(b.cacheDir, fmt.Sprintf("%03d_%s.mp4", index, reel.Code))
+	videoFile := b.videoFile(index, reel.Code)
 	pfpFile := filepath.Join(b.cacheDir, fmt.Sprintf("%03d_%s_pfp.jpg", index, reel.Code))
 
 	floatingPfpPaths := make([]FloatingPfpFile, len(reel.FloatingContextItems))
@@ -597,6 +1470,7 @@ func (b *ChromeBackend) Download(index int) (string, string, []FloatingPfpFile,
 
 	// check cache to see if already downloaded
 	if videoCache.has(videoFile) {
+		touchReelFiles(videoFile, pfpFile, floatingPfpPaths)
 		return videoFile, pfpFile, floatingPfpPaths, nil
 	}
 
@@ -631,8 +1505,8 @@ func (b *ChromeBackend) Download(index int) (string, string, []FloatingPfpFile,
 
 	// Download video, creator pfp, and any floating-context pfps in parallel.
 	// urls[0] is video, urls[1] is creator pfp (if present), then floating pfps.
-	urls := []string{reel.VideoURL}
-	hasCreatorPfp := reel.ProfilePicUrl != ""
+	urls := []string{videoURL}
+	hasCreatorPfp := reel.ProfilePicUrl != "" && GetSettings().ShowProfilePic
 	if hasCreatorPfp {
 		urls = append(urls, reel.ProfilePicUrl)
 	}
@@ -646,12 +1520,22 @@ func (b *ChromeBackend) Download(index int) (string, string, []FloatingPfpFile,
 		floatingIdx = append(floatingIdx, i)
 	}
 
-	data := fetchURLsHTTP(urls)
-	if data[0] == nil {
-		return "", "", nil, fmt.Errorf("failed to download video")
+	// the video goes through fetchVideo's strategies; pfps are plain HTTP
+	var video []byte
+	var videoErr error
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		video, videoErr = b.fetchVideo(videoURL)
+	}()
+	data := fetchURLsHTTP(append([]string{""}, urls[1:]...))
+	wg.Wait()
+	if videoErr != nil {
+		return "", "", nil, fmt.Errorf("failed to download video: %w", videoErr)
 	}
 
-	if err := os.WriteFile(videoFile, data[0], 0644); err != nil {
+	if err := writeVideo(videoFile, video); err != nil {
 		return "", "", nil, err
 	}
 	videoCache.add(videoFile)
@@ -670,3 +1554,69 @@ func (b *ChromeBackend) Download(index int) (string, string, []FloatingPfpFile,
 
 	return videoFile, pfpFile, floatingPfpPaths, nil
 }
+
+// Export copies the reel at index into destDir as <username>_<code>.mp4 (plus
+// its pfp as .jpg with the export_pfp setting) and returns the video's path.
+// The video comes from the cache, downloading it first or waiting for a
+// download in flight. A leading ~ in destDir is the home directory.
+func (b *ChromeBackend) Export(index int, destDir string) (string, error) {
+	pk := b.activeCursor().PKAt(index)
+	reel, ok := b.reelByPK(pk)
+	if pk == "" || !ok {
+		return "", fmt.Errorf("index out of range")
+	}
+
+	videoFile, pfpFile, _, err := b.Download(index)
+	if err != nil {
+		return "", err
+	}
+
+	if rest, ok := strings.CutPrefix(destDir, "~"); ok {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		destDir = filepath.Join(home, rest)
+	}
+	if err := os.MkdirAll(destDir, 0755); err != nil {
+		return "", fmt.Errorf("could not create download directory: %w", err)
+	}
+
+	name := fmt.Sprintf("%s_%s", reel.Username, reel.Code)
+	dest := filepath.Join(destDir, name+".mp4")
+	if err := copyFile(videoFile, dest); err != nil {
+		return "", err
+	}
+	if GetSettings().ExportPfp && reelPfpCache.has(pfpFile) {
+		if err := copyFile(pfpFile, filepath.Join(destDir, name+".jpg")); err != nil {
+			log.Printf("export pfp: %v", err)
+		}
+	}
+	return dest, nil
+}
+
+// copyFile copies src to dst, replacing dst. A failed copy leaves no partial
+// dst behind.
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	tmp := dst + ".part"
+	out, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		os.Remove(tmp)
+		return err
+	}
+	if err := out.Close(); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return os.Rename(tmp, dst)
+}
@@ -0,0 +1,194 @@
+package backend
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"syscall"
+	"testing"
+)
+
+// cacheFiles creates the named files in a temp dir and returns their paths.
+func cacheFiles(t *testing.T, names ...string) []string {
+	t.Helper()
+	dir := t.TempDir()
+	paths := make([]string, len(names))
+	for i, name := range names {
+		paths[i] = filepath.Join(dir, name)
+		if err := os.WriteFile(paths[i], []byte(name), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return paths
+}
+
+func exists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+func TestCacheEvictionOrder(t *testing.T) {
+	tests := []struct {
+		name    string
+		lru     bool
+		evicted string // which of a, b, c adding d pushes out after touching a
+	}{
+		{name: "fifo ignores touch", lru: false, evicted: "a"},
+		{name: "lru evicts least recently used", lru: true, evicted: "b"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			files := cacheFiles(t, "a", "b", "c", "d")
+			a, d := files[0], files[3]
+
+			c := newFIFOCache(3, tt.lru)
+			var evicted []string
+			c.onEvict = func(path string) { evicted = append(evicted, filepath.Base(path)) }
+			for _, p := range files[:3] {
+				c.add(p)
+			}
+			c.touch(a)
+			c.add(d)
+
+			if !slices.Equal(evicted, []string{tt.evicted}) {
+				t.Fatalf("evicted %v, want [%s]", evicted, tt.evicted)
+			}
+			for _, p := range files {
+				gone := filepath.Base(p) == tt.evicted
+				if c.has(p) == gone || exists(p) == gone {
+					t.Errorf("%s: cached=%v on disk=%v, want both %v", filepath.Base(p), c.has(p), exists(p), !gone)
+				}
+			}
+		})
+	}
+}
+
+func TestLRUTouchOrder(t *testing.T) {
+	files := cacheFiles(t, "a", "b", "c", "d", "e")
+	a, b, c := files[0], files[1], files[2]
+
+	cache := newFIFOCache(3, true)
+	for _, p := range files[:3] {
+		cache.add(p)
+	}
+	// a and b become the most recently used, in that order, leaving c oldest
+	cache.touch(a)
+	cache.touch(b)
+	// touching something never added changes nothing
+	cache.touch(files[4])
+
+	var evicted []string
+	cache.onEvict = func(path string) { evicted = append(evicted, filepath.Base(path)) }
+	cache.add(files[3])
+	cache.add(files[4])
+
+	if !slices.Equal(evicted, []string{"c", "a"}) {
+		t.Fatalf("evicted %v, want [c a]", evicted)
+	}
+	if !cache.has(b) || exists(c) {
+		t.Errorf("b cached=%v, c on disk=%v; want b kept and c removed", cache.has(b), exists(c))
+	}
+}
+
+func TestEvictionSkipsPinned(t *testing.T) {
+	files := cacheFiles(t, "a", "b", "c", "d")
+	a := files[0]
+
+	c := newFIFOCache(3, false)
+	for _, p := range files[:3] {
+		c.add(p)
+	}
+	c.pin(a)
+	c.add(files[3])
+	if n := c.evictOldest(10); n != 2 {
+		t.Errorf("evictOldest evicted %d, want 2", n)
+	}
+
+	for _, p := range files {
+		kept := p == a
+		if c.has(p) != kept || exists(p) != kept {
+			t.Errorf("%s: cached=%v on disk=%v, want both %v", filepath.Base(p), c.has(p), exists(p), kept)
+		}
+	}
+}
+
+// fullDisk makes writeFile fail with ENOSPC for the first failures calls,
+// leaving a partial file behind like a real short write would.
+func fullDisk(t *testing.T, failures int) {
+	t.Helper()
+	calls := 0
+	writeFile = func(name string, data []byte, perm fs.FileMode) error {
+		calls++
+		if calls <= failures {
+			os.WriteFile(name, data[:len(data)/2], perm)
+			return &fs.PathError{Op: "write", Path: name, Err: syscall.ENOSPC}
+		}
+		return os.WriteFile(name, data, perm)
+	}
+	t.Cleanup(func() { writeFile = os.WriteFile })
+}
+
+// useVideoCache swaps in a video cache holding files, oldest first.
+func useVideoCache(t *testing.T, files []string) *fifoCache {
+	t.Helper()
+	c := newFIFOCache(len(files), false)
+	for _, p := range files {
+		c.add(p)
+	}
+	saved := videoCache
+	videoCache = c
+	t.Cleanup(func() { videoCache = saved })
+	return c
+}
+
+func TestWriteVideoDiskFull(t *testing.T) {
+	t.Run("evicts and retries", func(t *testing.T) {
+		files := cacheFiles(t, "a", "b", "c", "d", "new")
+		cache := useVideoCache(t, files[:4])
+		cache.pin(files[0])
+		fullDisk(t, 1)
+
+		if err := writeVideo(files[4], []byte("video")); err != nil {
+			t.Fatalf("writeVideo = %v, want success after evicting", err)
+		}
+		if data, _ := os.ReadFile(files[4]); string(data) != "video" {
+			t.Errorf("wrote %q, want the whole video", data)
+		}
+		// half the cache goes, oldest first, but never the playing reel
+		for i, p := range files[:4] {
+			kept := i == 0 || i == 3
+			if cache.has(p) != kept || exists(p) != kept {
+				t.Errorf("%s: cached=%v on disk=%v, want both %v", filepath.Base(p), cache.has(p), exists(p), kept)
+			}
+		}
+	})
+
+	t.Run("still full", func(t *testing.T) {
+		files := cacheFiles(t, "a", "b", "new")
+		useVideoCache(t, files[:2])
+		fullDisk(t, 2)
+
+		if err := writeVideo(files[2], []byte("video")); !errors.Is(err, ErrDiskFull) {
+			t.Fatalf("writeVideo = %v, want ErrDiskFull", err)
+		}
+		if exists(files[2]) {
+			t.Error("truncated video left in the cache")
+		}
+	})
+
+	t.Run("nothing to evict", func(t *testing.T) {
+		files := cacheFiles(t, "a", "new")
+		cache := useVideoCache(t, files[:1])
+		cache.pin(files[0])
+		fullDisk(t, 1)
+
+		if err := writeVideo(files[1], []byte("video")); !errors.Is(err, ErrDiskFull) {
+			t.Fatalf("writeVideo = %v, want ErrDiskFull", err)
+		}
+		if exists(files[1]) || !exists(files[0]) {
+			t.Errorf("new on disk=%v, pinned on disk=%v; want only the pinned video", exists(files[1]), exists(files[0]))
+		}
+	})
+}
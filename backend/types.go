@@ -3,6 +3,9 @@ package backend
 import (
 	"context"
 	"sync"
+	"sync/atomic"
+
+	"github.com/chromedp/cdproto/fetch"
 )
 
 // ChromeBackend implements Backend using chromedp
@@ -54,6 +57,46 @@ type ChromeBackend struct {
 
 	events chan Event
 
+	// loginRequired is set once Instagram reports the session is logged out,
+	// so EventLoginRequired is only sent once until ReloadFeed recovers
+	loginRequired atomic.Bool
+
+	// confWarned is set after the first failed reels.conf write
+	confWarned atomic.Bool
+
+	// bodyWarned is set after the first intercepted response that couldn't
+	// be read, so EventResponsesLost is only sent once
+	bodyWarned atomic.Bool
+
+	// fetchBody reads a paused response's body (fetchResponseBody outside
+	// tests)
+	fetchBody func(ctx context.Context, id fetch.RequestID) ([]byte, error)
+
+	// stopping is closed by Stop so watchBrowser knows the browser going
+	// away is expected; watchDone is closed when watchBrowser returns. Both
+	// are made by NewChromeBackend; watching is set once Start launches
+	// watchBrowser, so Stop knows whether to wait for it.
+	stopping  chan struct{}
+	watchDone chan struct{}
+	watching  atomic.Bool
+
+	// storageErr is the initStorage failure, reported by Start
+	storageErr error
+
+	// startCode is the shortcode of a reel passed on the command line, opened
+	// by NavigateToReels in place of the generic feed
+	startCode string
+
+	// dumpTarget is the reel count --dump captures before exiting (0 outside
+	// dump mode); dumpDone is set once EventCaptureComplete has been sent
+	dumpTarget int
+	dumpDone   atomic.Bool
+
+	// userAgent is the browser's navigator.userAgent, read once for
+	// header-carrying downloads (see browserHeaders)
+	uaOnce    sync.Once
+	userAgent string
+
 	userDataDir string
 	cacheDir    string
 	configDir   string
@@ -75,12 +118,24 @@ type Backend interface {
 	// NavigateToReels goes to /reels and syncs to first captured reel
 	NavigateToReels() error
 
+	// ReloadFeed reloads /reels after the user logs back in. Captured reels
+	// are kept; the newly visible reel is appended to them.
+	ReloadFeed() error
+
 	// GetCurrent returns info about the currently visible reel in browser
 	GetCurrent() (*ReelInfo, error)
 
 	// GetReel returns reel info by index (1-based) from cache, no browser interaction
 	GetReel(index int) (*ReelInfo, error)
 
+	// PeekReel returns reel info offset positions from the current reel
+	// (1 = next, -1 = previous) from cache, no browser interaction
+	PeekReel(offset int) (*ReelInfo, error)
+
+	// GetComments returns a copy of the comments captured for the reel at
+	// index (1-based), safe to read while more are fetched
+	GetComments(index int) ([]Comment, error)
+
 	// GetTotal returns total number of captured reels
 	GetTotal() int
 
@@ -88,12 +143,26 @@ type Backend interface {
 	// Returns true if navbar should be shown, false if hidden.
 	ToggleNavbar() bool
 
+	// ToggleNavbarCompact switches the navbar between full hints and a
+	// one-line legend and persists the state. Returns true if compact.
+	ToggleNavbarCompact() bool
+
 	// SetVolume updates volume and persists to disk
 	SetVolume(vol float64) error
 
+	// SetMuted updates the mute state and persists to disk
+	SetMuted(muted bool) error
+
+	// SetBrightness updates the video brightness gain and persists to disk
+	SetBrightness(brightness float64) error
+
 	// SetReelSize updates the reel bounding box dimensions and persists to disk.
 	SetReelSize(width, height int) error
 
+	// SetCacheSize changes how many reels the video and pfp caches keep and
+	// persists it, evicting right away if the caches are over the new size
+	SetCacheSize(size int) error
+
 	// SyncTo scrolls browser to match the given index
 	// This is async-friendly - call it in background after optimistic UI update
 	SyncTo(index int) error
@@ -107,6 +176,24 @@ type Backend interface {
 	// ToggleSave bookmarks/unbookmarks the current reel
 	ToggleSave() (bool, error)
 
+	// ToggleCommentLike likes/unlikes a comment on the current reel by its PK
+	// and returns whether it's now liked
+	ToggleCommentLike(commentPK string) (bool, error)
+
+	// Export copies the reel at index (1-based) from the video cache into
+	// destDir as <username>_<code>.mp4, downloading it first if needed, and
+	// returns the saved path
+	Export(index int, destDir string) (string, error)
+
+	// RefreshCurrent re-fetches the current reel's like/comment/repost counts
+	// and liked/saved state, which are otherwise frozen at capture time, and
+	// returns the updated reel.
+	RefreshCurrent() (*ReelInfo, error)
+
+	// SaveResumeState records the reel code to reopen on the next launch when
+	// the resume setting is on; "" clears it
+	SaveResumeState(code string) error
+
 	// IsSyncing returns true if the backend is still scrolling to a reel, false otherwise
 	IsSyncing() bool
 
@@ -152,7 +239,16 @@ type Backend interface {
 	// context item pfps (reposts/likes from friends) to the cache directory.
 	Download(index int) (videoPath string, pfpPath string, floatingPfps []FloatingPfpFile, err error)
 
-	// Events returns a channel for backend events (new reels captured, etc)
+	// CachedVideo returns the reel's video path if it's already downloaded,
+	// or "" without downloading it.
+	CachedVideo(index int) string
+
+	// IsCached reports whether the reel's video is already downloaded. It
+	// only reads the cache and never blocks on a download in flight.
+	IsCached(index int) bool
+
+	// Events returns a channel for backend events (new reels captured, etc).
+	// Stop closes it; the backend is never restarted, so it isn't replaced.
 	Events() <-chan Event
 
 	// GetDMChats returns the chats with shared reels in DMs, grouped by
@@ -193,14 +289,19 @@ type Backend interface {
 }
 
 const (
-	// MaxRetries is the maximum number of scroll/retry attempts for sync operations
+	// MaxRetries is the default number of scroll/retry attempts for sync
+	// operations (see the sync_max_retries setting)
 	MaxRetries = 30
 
+	// KeyScrollStallLimit is how many ArrowUp/Down presses in a row may leave
+	// the visible reel unchanged before SyncTo falls back to scrolling via JS
+	KeyScrollStallLimit = 3
+
 	// InstagramPKLength is the length of Instagram primary keys (19 digits)
 	InstagramPKLength = 19
 
 	// FIFO cache limits per asset type
-	ReelCacheSize     = 50
+	ReelCacheSize     = 50   // default for the cache_size setting
 	GifCacheSize      = 1000 // surely your screen isn't big enough to store 1000 gifs
 	SharePfpCacheSize = 50
 	DMPfpCacheSize    = 1000 // surely you don't have 1000 friends
@@ -208,17 +309,24 @@ const (
 
 // MusicInfo contains song metadata when a reel has music
 type MusicInfo struct {
-	Title      string
-	Artist     string
-	IsExplicit bool
+	Title      string `json:"title"`
+	Artist     string `json:"artist"`
+	IsExplicit bool   `json:"is_explicit"`
+}
+
+// VideoVersion is one of the encodings Instagram offers for a reel's video
+type VideoVersion struct {
+	URL    string `json:"url"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
 }
 
 // FloatingContextItem represents a friend-activity badge on a reel,
 type FloatingContextItem struct {
-	Type          string // REPOSTED_BY, LIKED_BY, etc.
-	Username      string
-	ProfilePicUrl string
-	Text          string // media_note.text or comment.text, empty when absent
+	Type          string `json:"type"` // REPOSTED_BY, LIKED_BY, etc.
+	Username      string `json:"username"`
+	ProfilePicUrl string `json:"profile_pic_url"`
+	Text          string `json:"text,omitempty"` // media_note.text or comment.text, empty when absent
 }
 
 // Floating-context item types
@@ -236,25 +344,46 @@ type FloatingPfpFile struct {
 
 // Reel represents a single Instagram reel with metadata
 type Reel struct {
-	PK                   string
-	Code                 string
-	VideoURL             string
-	ProfilePicUrl        string
-	Username             string
-	Caption              string
-	Liked                bool
-	Saved                bool
-	Reposted             bool
-	LikeCount            int
-	RepostCount          int
-	IsVerified           bool
-	CommentCount         int
-	CommentsDisabled     bool
-	Music                *MusicInfo
-	CanViewerReshare     bool
-	FloatingContextItems []FloatingContextItem
-	Comments             []Comment           // cached comments (nil = not fetched yet)
-	CommentsPagination   *CommentsPagination // cached pagination state for resuming
+	PK                   string                `json:"pk"`
+	Code                 string                `json:"code"`
+	VideoURL             string                `json:"video_url"`      // first listed version
+	VideoVersions        []VideoVersion        `json:"video_versions"` // every encoding; Download picks one by video_quality
+	ProfilePicUrl        string                `json:"profile_pic_url"`
+	Username             string                `json:"username"`
+	Caption              string                `json:"caption"`
+	Liked                bool                  `json:"liked"`
+	Saved                bool                  `json:"saved"`
+	Reposted             bool                  `json:"reposted"`
+	LikeCount            int                   `json:"like_count"`
+	RepostCount          int                   `json:"repost_count"`
+	IsVerified           bool                  `json:"is_verified"`
+	CommentCount         int                   `json:"comment_count"`
+	CommentsDisabled     bool                  `json:"comments_disabled"`
+	Music                *MusicInfo            `json:"music,omitempty"`
+	CanViewerReshare     bool                  `json:"can_viewer_reshare"`
+	FloatingContextItems []FloatingContextItem `json:"floating_context_items,omitempty"`
+	Comments             []Comment             `json:"comments,omitempty"` // cached comments (nil = not fetched yet)
+	CommentsPagination   *CommentsPagination   `json:"-"`                  // cached pagination state for resuming
+}
+
+// videoURLFor returns the URL of the narrowest ("low") or widest ("high")
+// video version, falling back to VideoURL when the widths aren't known.
+func (r *Reel) videoURLFor(quality string) string {
+	best := -1
+	for i, v := range r.VideoVersions {
+		if v.Width <= 0 || v.URL == "" {
+			continue
+		}
+		if best == -1 ||
+			(quality == "high" && v.Width > r.VideoVersions[best].Width) ||
+			(quality != "high" && v.Width < r.VideoVersions[best].Width) {
+			best = i
+		}
+	}
+	if best == -1 {
+		return r.VideoURL
+	}
+	return r.VideoVersions[best].URL
 }
 
 // ReelInfo includes the reel data plus its position in the feed
@@ -274,18 +403,18 @@ type CommentsPagination struct {
 }
 
 type Comment struct {
-	PK                string // this is a pointer to the reel PK
-	CreatedAt         int64
-	ChildCommentCount int
-	ParentCommentID   string
-	ProfilePicUrl     string
-	Username          string
-	IsVerified        bool
-	HasLikedComment   bool
-	Text              string
-	CommentLikeCount  int
-	GifUrl            string
-	GifPath           string // local path to downloaded GIF file
+	PK                string `json:"pk"` // this is a pointer to the reel PK
+	CreatedAt         int64  `json:"created_at"`
+	ChildCommentCount int    `json:"child_comment_count"`
+	ParentCommentID   string `json:"parent_comment_id,omitempty"`
+	ProfilePicUrl     string `json:"profile_pic_url"`
+	Username          string `json:"username"`
+	IsVerified        bool   `json:"is_verified"`
+	HasLikedComment   bool   `json:"has_liked_comment"`
+	Text              string `json:"text"`
+	CommentLikeCount  int    `json:"comment_like_count"`
+	GifUrl            string `json:"gif_url,omitempty"`
+	GifPath           string `json:"-"` // local path to downloaded GIF file
 }
 
 // User represents an Instagram user: a share-modal list entry, the sender of a
@@ -307,10 +436,18 @@ const (
 	EventError
 	EventDMReelsReady
 	EventChatModeExited
+	EventSyncProgress
+	EventLoginRequired
+	EventConfigNotSaved
+	EventCaptureComplete // --dump: the requested number of reels is captured
+	EventStartReelMissed // the start reel wasn't captured; the feed starts elsewhere
+	EventResponsesLost   // an intercepted response couldn't be read; some reels or comments may be missing
 )
 
 // Event is sent from backend to frontend
 type Event struct {
 	Type  EventType
 	Count int
+	Total int   // EventSyncProgress: attempt limit; Count is the current attempt
+	Err   error // EventError: why the backend can't carry on, e.g. ErrBrowserDisconnected
 }
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/njyeung/reels/backend"
+	"github.com/njyeung/reels/player"
+	"github.com/njyeung/reels/player/shm"
+	"github.com/njyeung/reels/tui"
+)
+
+// runDoctor checks everything reels needs from the system (--doctor) and
+// prints a report. Returns false if any required check failed.
+func runDoctor(userDataDir string) bool {
+	healthy := true
+	report := func(ok, required bool, name, detail string) {
+		status := "ok  "
+		if !ok {
+			status = "warn"
+			if required {
+				status = "FAIL"
+				healthy = false
+			}
+		}
+		fmt.Printf("[%s] %-16s %s\n", status, name, detail)
+	}
+
+	// Chrome
+	if path := backend.FindChromium(userDataDir); path != "" {
+		detail := path
+		if out, err := exec.Command(path, "--version").Output(); err == nil {
+			detail = strings.TrimSpace(string(out)) + " (" + path + ")"
+		}
+		report(true, true, "chrome", detail)
+	} else {
+		report(false, false, "chrome", "not found: it will be downloaded on first run where supported")
+	}
+
+	// Terminal graphics
+	if player.KittyGraphicsSupported() {
+		report(true, true, "kitty graphics", "terminal answered the graphics query")
+	} else if player.SixelSupported() {
+		report(true, true, "sixel graphics", "no Kitty graphics: falling back to sixel")
+	} else {
+		report(false, false, "graphics", "no Kitty graphics or sixel: falling back to half-block characters (low resolution)")
+	}
+	if shm.ShmSupported() {
+		report(true, false, "shared memory", "frames go through shared memory")
+	} else {
+		report(false, false, "shared memory", "unsupported: frames are sent inline (slower)")
+	}
+	if player.SyncUpdateSupported() {
+		report(true, false, "sync updates", "terminal supports synchronized updates")
+	} else {
+		report(false, false, "sync updates", "unsupported: may flicker")
+	}
+	if cellW, cellH, ok := player.ReportedCellSize(); ok {
+		report(true, false, "cell size", fmt.Sprintf("%dx%d px", cellW, cellH))
+	} else {
+		report(false, false, "cell size", fmt.Sprintf("terminal reports %dx%d px: fallback_cell_width/height is used", cellW, cellH))
+	}
+
+	// FFmpeg
+	for _, name := range []string{"h264", "aac"} {
+		if player.DecoderAvailable(name) {
+			report(true, true, name+" decoder", "available")
+		} else {
+			report(false, true, name+" decoder", "missing from the linked FFmpeg")
+		}
+	}
+
+	// Clipboard
+	if tool := tui.ClipboardTool(); tool != "" {
+		report(true, false, "clipboard", tool)
+	} else {
+		report(false, false, "clipboard", "no pbcopy, wl-copy or xclip: copy keys won't work")
+	}
+
+	// Audio
+	if err := player.AudioAvailable(); err == nil {
+		report(true, false, "audio", "output device opened")
+	} else {
+		report(false, false, "audio", err.Error())
+	}
+
+	return healthy
+}
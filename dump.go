@@ -0,0 +1,99 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/njyeung/reels/backend"
+)
+
+// dumpStallLimit is how many scrolls in a row may capture nothing before
+// --dump gives up and prints what it has
+const dumpStallLimit = 5
+
+// runDump captures count reels without the TUI (--dump) and prints each one's
+// ReelInfo to stdout as a line of JSON. Videos are never downloaded. Returns
+// false if the feed couldn't be loaded.
+func runDump(userDataDir, logDir, cacheDir, configDir string, headed, headedSet, debug bool, startReel string, count int) bool {
+	backend.LoadSettings(configDir)
+	backend.InitLogger(logDir, debug)
+	if !headedSet {
+		headed = backend.GetSettings().Headed
+	}
+
+	b := backend.NewChromeBackend(userDataDir, cacheDir, configDir)
+	b.SetStartReel(startReel)
+	b.SetDumpMode(count)
+	defer b.Stop()
+
+	fail := func(err error) bool {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return false
+	}
+
+	if err := b.Start(!headed); err != nil {
+		return fail(err)
+	}
+	needsLogin, err := b.NeedsLogin()
+	if err != nil {
+		return fail(err)
+	}
+	if needsLogin {
+		return fail(fmt.Errorf("not logged in: run reels --login first"))
+	}
+	if err := b.NavigateToReels(); err != nil {
+		return fail(err)
+	}
+
+	// Scroll to the newest captured reel until the backend reports count
+	// captured, so the feed keeps loading more
+	stalls := 0
+	for done := b.GetTotal() >= count; !done; {
+		total := b.GetTotal()
+		if err := b.SyncTo(total); err != nil {
+			return fail(err)
+		}
+
+		deadline := time.After(3 * time.Second)
+	wait:
+		for {
+			select {
+			case ev := <-b.Events():
+				switch ev.Type {
+				case backend.EventCaptureComplete:
+					done = true
+					break wait
+				case backend.EventLoginRequired:
+					return fail(fmt.Errorf("logged out while capturing reels"))
+				case backend.EventError:
+					if ev.Err != nil {
+						return fail(ev.Err)
+					}
+				}
+			case <-deadline:
+				break wait
+			}
+		}
+
+		if b.GetTotal() > total {
+			stalls = 0
+		} else if stalls++; stalls >= dumpStallLimit {
+			fmt.Fprintf(os.Stderr, "feed stopped loading after %d reels\n", total)
+			break
+		}
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	for i := 1; i <= min(count, b.GetTotal()); i++ {
+		info, err := b.GetReel(i)
+		if err != nil {
+			continue
+		}
+		if err := enc.Encode(info); err != nil {
+			return fail(err)
+		}
+	}
+	return true
+}
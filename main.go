@@ -3,11 +3,16 @@ package main
 import (
 	"flag"
 	"fmt"
+	"net"
+	"net/http"
 	"os"
 	"path/filepath"
+	"strings"
 	"sync"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/njyeung/reels/backend"
 	"github.com/njyeung/reels/tui"
 )
 
@@ -19,17 +24,51 @@ type SyncFile struct {
 	*os.File
 }
 
+// listenAPI listens on addr for --serve. A bare :PORT binds to localhost
+// only: the API can drive the player, so it isn't exposed to the network
+// unless a host is given.
+func listenAPI(addr string) (net.Listener, error) {
+	if strings.HasPrefix(addr, ":") {
+		addr = "127.0.0.1" + addr
+	}
+	return net.Listen("tcp", addr)
+}
+
 func main() {
 	loginFlag := flag.Bool("login", false, "Open browser in headed mode for Instagram login, also used for debugging since the app does not try to control the browser.")
 	headedFlag := flag.Bool("headed", false, "Run browser in headed mode")
 	versionFlag := flag.Bool("version", false, "Print version and exit")
+	doctorFlag := flag.Bool("doctor", false, "Check Chrome, terminal graphics, FFmpeg, clipboard and audio support, print a report and exit")
+	dumpFlag := flag.Bool("dump", false, "Capture reels without the TUI, print each one's metadata as a line of JSON and exit")
+	countFlag := flag.Int("count", 10, "Number of reels --dump captures")
+	debugFlag := flag.Bool("debug", false, "Write a debug log to ~/.local/state/reels/reels.log and show A/V sync drift on the status line")
+	serveFlag := flag.String("serve", "", "Serve the captured reels and comments as JSON on `addr` (e.g. :8080) while the TUI runs")
 	flag.Parse()
 
+	// --headed overrides the headed setting only when given
+	headedSet := false
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == "headed" {
+			headedSet = true
+		}
+	})
+
 	if *versionFlag {
 		fmt.Println(Version)
 		return
 	}
 
+	// Optional positional arg: a reel URL or shortcode to open first
+	var startReel string
+	if flag.NArg() > 0 {
+		code, err := backend.ParseReelCode(flag.Arg(0))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		startReel = code
+	}
+
 	// If installed via npm and a newer release exists, swap the binary on disk
 	// and re-exec into it. Does nothing for non-npm installs or when already up
 	// to date. Must run before any child processes are spawned.
@@ -45,16 +84,57 @@ func main() {
 	cacheDir := filepath.Join(homeDir, ".cache", "reels")
 	configDir := filepath.Join(homeDir, ".config", "reels")
 
+	if *doctorFlag {
+		if !runDoctor(userDataDir) {
+			os.Exit(1)
+		}
+		return
+	}
+
+	if *dumpFlag {
+		if *countFlag < 1 {
+			fmt.Fprintln(os.Stderr, "Error: --count must be at least 1")
+			os.Exit(1)
+		}
+		if !runDump(userDataDir, logDir, cacheDir, configDir, *headedFlag, headedSet, *debugFlag, startReel, *countFlag) {
+			os.Exit(1)
+		}
+		return
+	}
+
 	// Create synchronized file wrapper for both Bubble Tea and video renderer
 	syncOut := &SyncFile{File: os.Stdout}
 
+	model := tui.NewModel(userDataDir, logDir, cacheDir, configDir, syncOut, Version, tui.Config{LoginMode: *loginFlag, HeadedMode: *headedFlag, HeadedSet: headedSet, StartReel: startReel, Debug: *debugFlag})
 	p := tea.NewProgram(
-		tui.NewModel(userDataDir, logDir, cacheDir, configDir, syncOut, Version, tui.Config{LoginMode: *loginFlag, HeadedMode: *headedFlag}),
+		model,
 		tea.WithAltScreen(),
 		tea.WithMouseCellMotion(),
 		tea.WithOutput(syncOut),
 	)
 
+	if *serveFlag != "" {
+		// Listen before the TUI takes over the screen so a bad address or a
+		// port in use is reported on the terminal
+		ln, err := listenAPI(*serveFlag)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: --serve: %v\n", err)
+			os.Exit(1)
+		}
+		handler := backend.NewAPIHandler(model.Backend(), func(index int) bool {
+			accepted := make(chan bool, 1)
+			p.Send(tui.NavigateTo(index, accepted))
+			// Send is a no-op once the TUI has quit, so don't wait forever
+			select {
+			case ok := <-accepted:
+				return ok
+			case <-time.After(time.Second):
+				return false
+			}
+		})
+		go http.Serve(ln, handler)
+	}
+
 	if _, err := p.Run(); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
@@ -2,20 +2,154 @@ package player
 
 import (
 	"fmt"
+	"math"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/asticode/go-astiav"
 	"github.com/gopxl/beep/v2"
 	"github.com/gopxl/beep/v2/speaker"
 )
 
-func init() {
-	// Initialize speaker at startup
-	format := beep.Format{
-		SampleRate: beep.SampleRate(AudioSampleRate),
+var (
+	speakerOnce   sync.Once
+	speakerErr    error
+	speakerMu     sync.Mutex
+	speakerBuffer = 50 * time.Millisecond
+)
+
+// SetAudioBuffer sets the speaker buffer length. Larger buffers help with
+// underruns (crackling), smaller ones reduce A/V lag. The speaker is
+// initialized when the first audio track plays, so later calls have no effect.
+func SetAudioBuffer(d time.Duration) {
+	if d <= 0 {
+		return
+	}
+	speakerMu.Lock()
+	defer speakerMu.Unlock()
+	speakerBuffer = d
+}
+
+// initSpeaker initializes the speaker on first use with the configured buffer.
+func initSpeaker() error {
+	speakerOnce.Do(func() {
+		speakerMu.Lock()
+		buffer := speakerBuffer
+		speakerMu.Unlock()
+
+		sampleRate := beep.SampleRate(AudioSampleRate)
+		speakerErr = speaker.Init(sampleRate, sampleRate.N(buffer))
+	})
+	return speakerErr
+}
+
+// AudioAvailable opens the audio device (as the first reel would) and returns
+// why it couldn't, if it couldn't.
+func AudioAvailable() error {
+	return initSpeaker()
+}
+
+// Loudness normalization (SetLoudness) levels reels toward normTargetRMS so
+// going from a quiet reel to a loud one doesn't jump in volume. The gain
+// follows a loudness estimate over several seconds, so it drifts instead of
+// pumping with each beat, except that it backs off within normAttack when the
+// audio gets louder. The estimate carries over from reel to reel and across
+// loops, and samples the gain pushes past normLimit are softly limited rather
+// than clipped.
+const (
+	normTargetRMS = 0.1 // about -20 dBFS
+	normMinGain   = 0.25
+	normMaxGain   = 4
+	normGateRMS   = 0.003                  // quieter frames (silence, fades) leave the estimate alone
+	normSeed      = time.Second            // audio above the gate averaged before the gain moves
+	normWindow    = 3 * time.Second        // loudness estimate time constant
+	normGainSlew  = time.Second            // time constant of the gain following it
+	normAttack    = 300 * time.Millisecond // both time constants when it gets louder
+	normLimit     = 0.7                    // level (of full scale) the soft limiter starts at
+)
+
+// loudness is the normalization state. AVPlayer keeps one for its lifetime
+// and hands it to each session's AudioPlayer, so a new reel or loop starts
+// from the level and gain the last one ended on.
+type loudness struct {
+	mu sync.Mutex
+	// level is the running mean square, 0 until normSeed of audio above the
+	// gate has been averaged into seedSum over seedSecs
+	level    float64
+	seedSum  float64
+	seedSecs float64
+	// gain is the gain the last frame ended on (0 before the first)
+	gain float64
+}
+
+// apply levels a decoded frame of s16 stereo samples in place. The loudness
+// estimate takes in the frame, then the gain moves toward the one that brings
+// the estimate to normTargetRMS, ramping across the frame so it never steps.
+func (l *loudness) apply(buf []byte) {
+	n := len(buf) / 2 // samples, counting each channel
+	if n == 0 {
+		return
+	}
+	var sum float64
+	for i := 0; i+1 < len(buf); i += 2 {
+		v := float64(int16(buf[i])|int16(buf[i+1])<<8) / 32768
+		sum += v * v
+	}
+	frameSecs := float64(n/2) / AudioSampleRate
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if meanSq := sum / float64(n); meanSq >= normGateRMS*normGateRMS {
+		if l.level > 0 {
+			window := normWindow
+			if meanSq > l.level {
+				window = normAttack
+			}
+			l.level += (1 - math.Exp(-frameSecs/window.Seconds())) * (meanSq - l.level)
+		} else {
+			l.seedSum += meanSq * frameSecs
+			l.seedSecs += frameSecs
+			if l.seedSecs >= normSeed.Seconds() {
+				l.level = l.seedSum / l.seedSecs
+			}
+		}
+	}
+
+	target := 1.0
+	if l.level > 0 {
+		target = min(max(normTargetRMS/math.Sqrt(l.level), normMinGain), normMaxGain)
+	}
+	from := l.gain
+	if from == 0 {
+		from = target
+	}
+	slew := normGainSlew
+	if target < from {
+		slew = normAttack
+	}
+	to := from + (1-math.Exp(-frameSecs/slew.Seconds()))*(target-from)
+	l.gain = to
+
+	for i := 0; i+1 < len(buf); i += 2 {
+		gain := from + (to-from)*float64(i)/float64(len(buf))
+		v := softLimit(float64(int16(buf[i])|int16(buf[i+1])<<8) / 32768 * gain)
+		s := int16(min(max(v*32768, math.MinInt16), math.MaxInt16))
+		buf[i], buf[i+1] = byte(s), byte(s>>8)
 	}
-	speaker.Init(format.SampleRate, format.SampleRate.N(50*1000000)) // 50ms buffer
+}
+
+// softLimit passes v (full scale = 1) through unchanged up to normLimit and
+// bends anything louder smoothly toward full scale, so a quiet passage's
+// boosted gain meeting a loud one compresses it instead of clipping.
+func softLimit(v float64) float64 {
+	a := math.Abs(v)
+	if a <= normLimit {
+		return v
+	}
+	a = normLimit + (1-normLimit)*math.Tanh((a-normLimit)/(1-normLimit))
+	return math.Copysign(a, v)
 }
 
 // AudioPlayer decodes and plays audio, providing the master clock
@@ -32,6 +166,10 @@ type AudioPlayer struct {
 	muted   atomic.Bool
 	volume  atomic.Value // float64, 0.0–1.0
 
+	// peak is the highest absolute sample (0-32768) consumed since the last
+	// PeakLevel call, before volume and mute
+	peak atomic.Uint32
+
 	// Beep streamer
 	streamer *audioStreamer
 	ctrl     *beep.Ctrl
@@ -40,6 +178,17 @@ type AudioPlayer struct {
 	sampleBuf []byte
 	buffMu    sync.Mutex
 
+	// Fade-out on Close. When fadeOut > 0, Close leaves the streamer in the
+	// speaker and it ramps the remaining buffered samples down to silence over
+	// fadeTotal samples, then drains itself. Guarded by buffMu.
+	fadeOut   time.Duration
+	fadeTotal int
+	fadeLeft  int
+
+	// Loudness normalization applied as frames are decoded, nil when off.
+	// Guarded by mu.
+	norm *loudness
+
 	closed bool
 	mu     sync.Mutex
 }
@@ -56,6 +205,13 @@ func (s *audioStreamer) Stream(samples [][2]float64) (n int, ok bool) {
 	s.player.buffMu.Lock()
 	defer s.player.buffMu.Unlock()
 
+	fading := s.player.fadeTotal > 0
+
+	// a paused reel has nothing audible left to fade, drop out of the mixer
+	if fading && (s.player.paused.Load() || s.player.fadeLeft <= 0) {
+		return 0, false
+	}
+
 	// when paused, fill buff with silence and don't advance clock
 	if s.player.paused.Load() {
 		for i := range samples {
@@ -79,9 +235,15 @@ func (s *audioStreamer) Stream(samples [][2]float64) (n int, ok bool) {
 	// (4 bytes = 1 stereo sample)
 	bytesPerSample := 4 // (s16le stereo)
 	samplesPlayed := 0
+	peak := 0
 
 	for i := range samples {
 
+		if fading && (len(s.player.sampleBuf) < bytesPerSample || s.player.fadeLeft <= 0) {
+			// fade finished or ran out of buffered audio, drain from the mixer
+			return i, false
+		}
+
 		if len(s.player.sampleBuf) < bytesPerSample {
 			// no more data, fill rest with silence but keep streaming
 			for j := i; j < len(samples); j++ {
@@ -91,6 +253,11 @@ func (s *audioStreamer) Stream(samples [][2]float64) (n int, ok bool) {
 			break
 		}
 
+		// the meter shows what the reel has, so peak ignores volume and mute
+		left := int16(s.player.sampleBuf[0]) | int16(s.player.sampleBuf[1])<<8
+		right := int16(s.player.sampleBuf[2]) | int16(s.player.sampleBuf[3])<<8
+		peak = max(peak, absInt16(left), absInt16(right))
+
 		if muted {
 			// consume buffer but output silence
 			samples[i][0] = 0
@@ -102,10 +269,16 @@ func (s *audioStreamer) Stream(samples [][2]float64) (n int, ok bool) {
 			// left low byte | left high byte << 8
 			// 	  8 bits            8 bits
 			const MAX_INT_16 = int16(32767)
-			left := int16(s.player.sampleBuf[0]) | int16(s.player.sampleBuf[1])<<8
-			right := int16(s.player.sampleBuf[2]) | int16(s.player.sampleBuf[3])<<8
-			samples[i][0] = float64(left) / float64(MAX_INT_16) * volume
-			samples[i][1] = float64(right) / float64(MAX_INT_16) * volume
+			gain := volume
+			if fading {
+				gain *= float64(s.player.fadeLeft) / float64(s.player.fadeTotal)
+			}
+			samples[i][0] = float64(left) / float64(MAX_INT_16) * gain
+			samples[i][1] = float64(right) / float64(MAX_INT_16) * gain
+		}
+
+		if fading {
+			s.player.fadeLeft--
 		}
 
 		// consume
@@ -119,15 +292,32 @@ func (s *audioStreamer) Stream(samples [][2]float64) (n int, ok bool) {
 		s.player.clock.Store(s.player.clock.Load().(float64) + float64(samplesPlayed)/float64(AudioSampleRate))
 	}
 
+	for {
+		prev := s.player.peak.Load()
+		if uint32(peak) <= prev || s.player.peak.CompareAndSwap(prev, uint32(peak)) {
+			break
+		}
+	}
 	return len(samples), true
 }
 
+func absInt16(v int16) int {
+	if v < 0 {
+		return -int(v)
+	}
+	return int(v)
+}
+
 func (s *audioStreamer) Err() error {
 	return nil
 }
 
 // NewAudioPlayer creates an audio player from codec parameters
 func NewAudioPlayer(codecParams *astiav.CodecParameters) (*AudioPlayer, error) {
+	if err := initSpeaker(); err != nil {
+		return nil, fmt.Errorf("failed to initialize speaker: %w", err)
+	}
+
 	a := &AudioPlayer{
 		sampleBuf: make([]byte, 0, 192000), // ~1 second buffer
 	}
@@ -241,7 +431,11 @@ func (a *AudioPlayer) DecodePacket(pkt *astiav.Packet, pts float64) error {
 			plane, err := data.Bytes(0)
 			if err == nil && plane != nil && len(plane) >= byteSize {
 				a.buffMu.Lock()
+				start := len(a.sampleBuf)
 				a.sampleBuf = append(a.sampleBuf, plane[:byteSize]...)
+				if a.norm != nil {
+					a.norm.apply(a.sampleBuf[start:])
+				}
 				a.buffMu.Unlock()
 			}
 		}
@@ -253,11 +447,26 @@ func (a *AudioPlayer) DecodePacket(pkt *astiav.Packet, pts float64) error {
 	return nil
 }
 
+// SetLoudness levels frames decoded from now on with the shared
+// normalization state l, or stops leveling them if l is nil. The volume still
+// applies on top of it.
+func (a *AudioPlayer) SetLoudness(l *loudness) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.norm = l
+}
+
 // Time returns the current playback time (master clock)
 func (a *AudioPlayer) Time() float64 {
 	return a.clock.Load().(float64)
 }
 
+// PeakLevel returns the peak amplitude (0-1) of the audio played since the
+// last call, before volume and mute, and starts a new window.
+func (a *AudioPlayer) PeakLevel() float64 {
+	return float64(a.peak.Swap(0)) / 32768
+}
+
 // SetVolume sets the playback volume (0.0–1.0)
 func (a *AudioPlayer) SetVolume(vol float64) {
 	a.volume.Store(vol)
@@ -278,6 +487,14 @@ func (a *AudioPlayer) Pause() {
 	a.paused.Store(!a.paused.Load())
 }
 
+// SetFadeOut sets how long Close keeps the buffered audio playing while fading
+// it to silence. Zero cuts the audio immediately.
+func (a *AudioPlayer) SetFadeOut(d time.Duration) {
+	a.buffMu.Lock()
+	defer a.buffMu.Unlock()
+	a.fadeOut = d
+}
+
 // Seek clears buffered audio samples and resets the clock to the given position.
 func (a *AudioPlayer) Seek(seconds float64) {
 	a.buffMu.Lock()
@@ -298,7 +515,19 @@ func (a *AudioPlayer) Close() {
 	a.closed = true
 
 	a.playing.Store(false)
-	speaker.Clear()
+
+	// Hand the streamer its fade budget and let it drain itself, so the next
+	// session can start while this one's tail is still audible.
+	a.buffMu.Lock()
+	fadeOut := a.fadeOut
+	if fadeOut > 0 {
+		a.fadeTotal = max(int(fadeOut.Seconds()*AudioSampleRate), 1)
+		a.fadeLeft = a.fadeTotal
+	}
+	a.buffMu.Unlock()
+	if fadeOut <= 0 {
+		speaker.Clear()
+	}
 
 	if a.frame != nil {
 		a.frame.Free()
@@ -0,0 +1,53 @@
+package player
+
+import (
+	"math"
+	"testing"
+)
+
+// streamOnce feeds n stereo samples of value v (s16) through Stream at volume
+// and mute, and returns what it sent to the speaker.
+func streamOnce(t *testing.T, v int16, n int, volume float64, muted bool) [][2]float64 {
+	t.Helper()
+	a := &AudioPlayer{}
+	a.clock.Store(0.0)
+	a.volume.Store(volume)
+	a.muted.Store(muted)
+	for range n {
+		a.sampleBuf = append(a.sampleBuf, byte(v), byte(v>>8), byte(v), byte(v>>8))
+	}
+	a.streamer = &audioStreamer{player: a}
+
+	out := make([][2]float64, n)
+	if got, ok := a.streamer.Stream(out); got != n || !ok {
+		t.Fatalf("Stream = %d, %v; want %d, true", got, ok, n)
+	}
+	if len(a.sampleBuf) != 0 {
+		t.Errorf("%d bytes left in the buffer, want all of it consumed", len(a.sampleBuf))
+	}
+	return out
+}
+
+func TestStreamMuteOutputsSilence(t *testing.T) {
+	for i, s := range streamOnce(t, 16384, 64, 1, true) {
+		if s != [2]float64{} {
+			t.Fatalf("sample %d = %v, want silence while muted", i, s)
+		}
+	}
+}
+
+func TestStreamVolumeIsSquared(t *testing.T) {
+	full := streamOnce(t, 16384, 64, 1, false)
+	half := streamOnce(t, 16384, 64, 0.5, false)
+	for i := range full {
+		// volume 0.5 plays at a quarter of the amplitude, not half
+		for ch := range 2 {
+			if want := full[i][ch] * 0.25; math.Abs(half[i][ch]-want) > 1e-9 {
+				t.Fatalf("sample %d channel %d = %v at volume 0.5, want %v", i, ch, half[i][ch], want)
+			}
+		}
+	}
+	if full[0][0] == 0 {
+		t.Fatal("full-volume output is silent")
+	}
+}
@@ -67,7 +67,37 @@ func LoadGif(path string, heightPx int) (*GifAnimation, error) {
 		frames[i] = scaleRGBA(img, dstW, dstH, false)
 	}
 
-	delays := make([]time.Duration, len(frames))
+	return &GifAnimation{
+		Frames: frames,
+		Delays: gifDelays(g, len(frames)),
+		Width:  dstW,
+		Height: dstH,
+	}, nil
+}
+
+// frameAt returns the frame shown at elapsed time into a looping playback.
+func (g *GifAnimation) frameAt(elapsed time.Duration) []byte {
+	var total time.Duration
+	for _, d := range g.Delays {
+		total += d
+	}
+	if total <= 0 {
+		return g.Frames[0]
+	}
+	elapsed %= total
+	for i, d := range g.Delays {
+		if elapsed < d {
+			return g.Frames[i]
+		}
+		elapsed -= d
+	}
+	return g.Frames[len(g.Frames)-1]
+}
+
+// gifDelays returns the display duration of each of the first n frames,
+// substituting 100ms for missing or too-short (< 20ms) delays like browsers do.
+func gifDelays(g *gif.GIF, n int) []time.Duration {
+	delays := make([]time.Duration, n)
 	for i := range delays {
 		if i < len(g.Delay) {
 			delays[i] = time.Duration(g.Delay[i]) * 10 * time.Millisecond
@@ -76,13 +106,7 @@ func LoadGif(path string, heightPx int) (*GifAnimation, error) {
 			delays[i] = 100 * time.Millisecond
 		}
 	}
-
-	return &GifAnimation{
-		Frames: frames,
-		Delays: delays,
-		Width:  dstW,
-		Height: dstH,
-	}, nil
+	return delays
 }
 
 // compositeGifFrames renders GIF frames onto a canvas, respecting disposal modes.
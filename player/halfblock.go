@@ -0,0 +1,293 @@
+package player
+
+import (
+	"bytes"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"strconv"
+	"sync"
+)
+
+// HalfBlockRenderer draws images as text: each cell is a ▀ with the top pixel
+// as its truecolor foreground and the bottom one as its background. It's the
+// last resort for terminals (tmux, ssh sessions) with no graphics protocol, so
+// it trades resolution for working everywhere.
+type HalfBlockRenderer struct {
+	mu sync.Mutex
+
+	out io.Writer
+
+	// Terminal dimensions in cells and pixels
+	termCols     int
+	termRows     int
+	termWidthPx  int
+	termHeightPx int
+
+	syncUpdate bool
+
+	renderCache map[int]renderCacheEntry
+	placed      map[int]cellRect // where each image ID was last drawn
+}
+
+// NewHalfBlockRenderer creates a new half-block renderer
+func NewHalfBlockRenderer(out io.Writer) *HalfBlockRenderer {
+	return &HalfBlockRenderer{out: out}
+}
+
+// SetUseShm is a no-op: half blocks are plain text.
+func (r *HalfBlockRenderer) SetUseShm(useShm bool) {}
+
+// SetSyncUpdate enables or disables the synchronized-update escapes.
+func (r *HalfBlockRenderer) SetSyncUpdate(syncUpdate bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.syncUpdate = syncUpdate
+}
+
+// SetOutput changes the output writer
+func (r *HalfBlockRenderer) SetOutput(w io.Writer) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.out = w
+}
+
+// SetTerminalSize sets the terminal dimensions (cells and pixels)
+func (r *HalfBlockRenderer) SetTerminalSize(cols, rows, widthPx, heightPx int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.termCols = cols
+	r.termRows = rows
+	r.termWidthPx = widthPx
+	r.termHeightPx = heightPx
+}
+
+// rectFor returns the cells a width x height image placed at row, col covers.
+// Video frames fill the VideoWidthChars x VideoHeightChars box the layout
+// reserved for them; other images are sized from the cell size in pixels.
+func (r *HalfBlockRenderer) rectFor(width, height, id, row, col int) cellRect {
+	rect := cellRect{row: max(row, 1), col: max(col, 1)}
+	if id == VideoImageID || id == VideoBackID {
+		rect.rows, rect.cols = VideoHeightChars, VideoWidthChars
+		return rect
+	}
+	cellW, cellH := fallbackCellW, fallbackCellH
+	if r.termCols > 0 && r.termRows > 0 && r.termWidthPx > 0 && r.termHeightPx > 0 {
+		cellW, cellH = r.termWidthPx/r.termCols, r.termHeightPx/r.termRows
+	}
+	rect.rows = max((height+cellH-1)/cellH, 1)
+	rect.cols = max((width+cellW-1)/cellW, 1)
+	return rect
+}
+
+// RenderImage renders image data at the given cell position. id only tracks
+// the placement so a later Prune can erase it. format: 24 (RGB24) or 32 (RGBA).
+func (r *HalfBlockRenderer) RenderImage(data []byte, format, width, height, id, row, col int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entry := renderCacheEntry{
+		dataChecksum: crc32.ChecksumIEEE(data),
+		dataLen:      len(data),
+		format:       format,
+		width:        width,
+		height:       height,
+		row:          row,
+		col:          col,
+	}
+	if r.renderCache != nil {
+		if prev, ok := r.renderCache[id]; ok && prev == entry {
+			return nil
+		}
+	} else {
+		r.renderCache = make(map[int]renderCacheEntry)
+		r.placed = make(map[int]cellRect)
+	}
+	r.renderCache[id] = entry
+
+	rect := r.rectFor(width, height, id, row, col)
+
+	var buf bytes.Buffer
+
+	// Save cursor position
+	buf.WriteString("\x1b7")
+
+	// Transparent cells are skipped, not drawn, so clear what the previous
+	// image under this ID left behind
+	if prev, ok := r.placed[id]; ok {
+		if format == 32 {
+			eraseRect(&buf, prev, nil, r.termRows)
+		} else {
+			eraseRect(&buf, prev, []cellRect{rect}, r.termRows)
+		}
+	}
+	r.placed[id] = rect
+
+	r.draw(&buf, data, format, width, height, rect)
+
+	// Reset colors, restore cursor position
+	buf.WriteString("\x1b[0m\x1b8")
+
+	_, err := r.out.Write(buf.Bytes())
+	return err
+}
+
+// draw scales the image down to rect's cells, two pixels per cell, and writes
+// them as half blocks. Each scaled pixel is the average of the source pixels
+// it covers.
+func (r *HalfBlockRenderer) draw(buf *bytes.Buffer, data []byte, format, width, height int, rect cellRect) {
+	bpp := format / 8
+	if width <= 0 || height <= 0 || len(data) < width*height*bpp {
+		return
+	}
+
+	// pixel averages the source block behind scaled pixel (x, y) of a
+	// cols x rows*2 grid. ok is false if it's mostly transparent.
+	pixel := func(x, y int) (red, green, blue int, ok bool) {
+		x0, x1 := x*width/rect.cols, max((x+1)*width/rect.cols, x*width/rect.cols+1)
+		y0, y1 := y*height/(rect.rows*2), max((y+1)*height/(rect.rows*2), y*height/(rect.rows*2)+1)
+		var n, opaque int
+		for sy := y0; sy < min(y1, height); sy++ {
+			line := data[sy*width*bpp:]
+			for sx := x0; sx < min(x1, width); sx++ {
+				p := line[sx*bpp:]
+				n++
+				if bpp == 4 && p[3] < alphaThreshold {
+					continue
+				}
+				red += int(p[0])
+				green += int(p[1])
+				blue += int(p[2])
+				opaque++
+			}
+		}
+		if opaque == 0 || opaque*2 < n {
+			return 0, 0, 0, false
+		}
+		return red / opaque, green / opaque, blue / opaque, true
+	}
+
+	type color struct{ r, g, b int }
+	var fg, bg color
+	fgSet, bgSet := false, false
+	setFg := func(c color) {
+		if !fgSet || fg != c {
+			buf.WriteString("\x1b[38;2;" + strconv.Itoa(c.r) + ";" + strconv.Itoa(c.g) + ";" + strconv.Itoa(c.b) + "m")
+			fg, fgSet = c, true
+		}
+	}
+	setBg := func(c color) {
+		if !bgSet || bg != c {
+			buf.WriteString("\x1b[48;2;" + strconv.Itoa(c.r) + ";" + strconv.Itoa(c.g) + ";" + strconv.Itoa(c.b) + "m")
+			bg, bgSet = c, true
+		}
+	}
+
+	for cy := 0; cy < rect.rows; cy++ {
+		row := rect.row + cy
+		if r.termRows > 0 && row > r.termRows {
+			break
+		}
+		fmt.Fprintf(buf, "\x1b[%d;%dH", row, rect.col)
+		for cx := 0; cx < rect.cols; cx++ {
+			tr, tg, tb, topOK := pixel(cx, cy*2)
+			br, bgr, bb, bottomOK := pixel(cx, cy*2+1)
+			top, bottom := color{tr, tg, tb}, color{br, bgr, bb}
+
+			switch {
+			case topOK && bottomOK:
+				setFg(top)
+				setBg(bottom)
+				buf.WriteString("▀")
+			case topOK || bottomOK:
+				// one half transparent: the default background shows there
+				if bgSet {
+					buf.WriteString("\x1b[49m")
+					bgSet = false
+				}
+				if topOK {
+					setFg(top)
+					buf.WriteString("▀")
+				} else {
+					setFg(bottom)
+					buf.WriteString("▄")
+				}
+			default:
+				buf.WriteString("\x1b[C") // fully transparent: leave the cell
+			}
+		}
+	}
+}
+
+// BeginSync emits the synchronized-update start escape so the terminal buffers
+// subsequent renders until EndSync is called.
+func (r *HalfBlockRenderer) BeginSync() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.syncUpdate {
+		return
+	}
+	r.out.Write([]byte("\x1b[?2026h"))
+}
+
+// EndSync commits all buffered renders to the screen.
+func (r *HalfBlockRenderer) EndSync() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.syncUpdate {
+		return
+	}
+	r.out.Write([]byte("\x1b[?2026l"))
+}
+
+// Prune erases every placed image whose ID is not in keep, leaving the cells
+// kept images cover alone.
+func (r *HalfBlockRenderer) Prune(keep map[int]bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var kept []cellRect
+	for id, rect := range r.placed {
+		if keep[id] {
+			kept = append(kept, rect)
+		}
+	}
+
+	var buf bytes.Buffer
+	for id, rect := range r.placed {
+		if keep[id] {
+			continue
+		}
+		delete(r.placed, id)
+		delete(r.renderCache, id)
+		eraseRect(&buf, rect, kept, r.termRows)
+	}
+	if buf.Len() == 0 {
+		return
+	}
+	r.out.Write([]byte("\x1b7"))
+	r.out.Write(buf.Bytes())
+	r.out.Write([]byte("\x1b8"))
+}
+
+// DeleteAllImages erases every placed image.
+func (r *HalfBlockRenderer) DeleteAllImages() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var buf bytes.Buffer
+	for _, rect := range r.placed {
+		eraseRect(&buf, rect, nil, r.termRows)
+	}
+	r.renderCache = nil
+	r.placed = nil
+	if buf.Len() == 0 {
+		return
+	}
+	r.out.Write([]byte("\x1b7"))
+	r.out.Write(buf.Bytes())
+	r.out.Write([]byte("\x1b8"))
+}
+
+// CleanupShm is a no-op: the half-block renderer never uses shared memory.
+func (r *HalfBlockRenderer) CleanupShm() {}
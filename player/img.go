@@ -1,14 +1,29 @@
 package player
 
 import (
+	"bytes"
 	"fmt"
 	"image"
+	"image/gif"
 	_ "image/jpeg"
 	_ "image/png"
 	"os"
 	"sync"
+	"sync/atomic"
+	"time"
 )
 
+// animatedPFP makes LoadPFP keep every frame of an animated (GIF) profile
+// picture instead of just the first. Off by default since it adds per-frame
+// render work.
+var animatedPFP atomic.Bool
+
+// SetAnimatedPFP enables or disables animated profile pictures for pfps
+// loaded afterwards.
+func SetAnimatedPFP(on bool) {
+	animatedPFP.Store(on)
+}
+
 // Img is a decoded image and its latest rendered RGBA buffer. Circular selects
 // a masked avatar (profile pictures, icon badges) versus a square, alpha-
 // preserving overlay (emoji reactions).
@@ -19,6 +34,14 @@ type Img struct {
 	width    int
 	height   int
 	circular bool
+
+	// srcFrames, delays and anim are set for animated pfps: the composited
+	// source frames, and the frames scaled by Resize. Snapshot picks the
+	// frame to show from the time elapsed since start.
+	srcFrames []*image.RGBA
+	delays    []time.Duration
+	anim      *GifAnimation
+	start     time.Time
 }
 
 // ImageSlot describes a static image to display at a terminal cell position.
@@ -34,14 +57,14 @@ type visibleImage struct {
 }
 
 // LoadPFP decodes a profile image from disk. Profile pictures render circular.
+// With SetAnimatedPFP on, an animated GIF keeps all its frames and loops.
 func LoadPFP(path string) (*Img, error) {
-	f, err := os.Open(path)
+	data, err := os.ReadFile(path)
 	if err != nil {
 		return nil, fmt.Errorf("open pfp: %w", err)
 	}
-	defer f.Close()
 
-	img, _, err := image.Decode(f)
+	img, format, err := image.Decode(bytes.NewReader(data))
 	if err != nil {
 		return nil, fmt.Errorf("decode pfp: %w", err)
 	}
@@ -51,9 +74,75 @@ func LoadPFP(path string) (*Img, error) {
 		return nil, fmt.Errorf("pfp has zero dimensions")
 	}
 
-	return &Img{src: img, circular: true}, nil
+	p := &Img{src: img, circular: true}
+	if format == "gif" && animatedPFP.Load() {
+		if g, err := gif.DecodeAll(bytes.NewReader(data)); err == nil && len(g.Image) > 1 {
+			p.srcFrames = compositeGifFrames(g)
+			p.delays = gifDelays(g, len(p.srcFrames))
+			p.start = time.Now()
+		}
+	}
+	return p, nil
+}
+
+// LoadThumbnail decodes the first video frame of a local file into a square
+// (uncropped, unmasked) image for the grid overview.
+func LoadThumbnail(path string) (*Img, error) {
+	demuxer, err := NewDemuxer(path)
+	if err != nil {
+		return nil, err
+	}
+	defer demuxer.Close()
+
+	video, err := NewVideoDecoder(demuxer.VideoCodecParameters(), demuxer.VideoTimeBase())
+	if err != nil {
+		return nil, err
+	}
+	defer video.Close()
+
+	// decode small; ResizeToCells scales it down further anyway
+	srcW, srcH := video.SourceSize()
+	video.SetSize(fitSize(srcW, srcH, thumbMaxW, thumbMaxH))
+
+	for range thumbMaxPackets {
+		pkt, isVideo, err := demuxer.ReadPacket()
+		if err != nil {
+			return nil, fmt.Errorf("no frame in %s: %w", path, err)
+		}
+		if !isVideo {
+			pkt.Free()
+			continue
+		}
+
+		frame, err := video.DecodePacket(pkt)
+		pkt.Free()
+		if err != nil {
+			return nil, err
+		}
+		if frame == nil {
+			continue
+		}
+
+		rgba := image.NewRGBA(image.Rect(0, 0, frame.Width, frame.Height))
+		for i, j := 0, 0; i+2 < len(frame.RGB); i, j = i+3, j+4 {
+			rgba.Pix[j] = frame.RGB[i]
+			rgba.Pix[j+1] = frame.RGB[i+1]
+			rgba.Pix[j+2] = frame.RGB[i+2]
+			rgba.Pix[j+3] = 0xff
+		}
+		return &Img{src: rgba}, nil
+	}
+	return nil, fmt.Errorf("no frame in first %d packets of %s", thumbMaxPackets, path)
 }
 
+// thumbMaxW/thumbMaxH bound the decode size for LoadThumbnail, and
+// thumbMaxPackets caps how far into the file it looks for a first frame
+const (
+	thumbMaxW       = 180
+	thumbMaxH       = 320
+	thumbMaxPackets = 300
+)
+
 // ResizeToCells scales the image to a target number of terminal cells.
 func (p *Img) ResizeToCells(cellsTall int) error {
 	if p == nil {
@@ -107,11 +196,23 @@ func (p *Img) Resize(targetHeightPx int) {
 	p.rgba = scaleRGBA(p.src, dstW, dstH, p.circular)
 	p.width = dstW
 	p.height = dstH
+
+	if len(p.srcFrames) > 0 {
+		frames := make([][]byte, len(p.srcFrames))
+		for i, f := range p.srcFrames {
+			frames[i] = scaleRGBA(f, dstW, dstH, p.circular)
+		}
+		p.anim = &GifAnimation{Frames: frames, Delays: p.delays, Width: dstW, Height: dstH}
+	}
 }
 
-// Snapshot returns the latest RGBA buffer and dimensions.
+// Snapshot returns the latest RGBA buffer and dimensions. For an animated pfp
+// this is the frame due at the current time.
 func (p *Img) Snapshot() (rgba []byte, width, height int) {
 	p.mu.RLock()
 	defer p.mu.RUnlock()
+	if p.anim != nil {
+		return p.anim.frameAt(time.Since(p.start)), p.width, p.height
+	}
 	return p.rgba, p.width, p.height
 }
@@ -0,0 +1,18 @@
+package player
+
+import (
+	"strings"
+
+	"github.com/njyeung/reels/player/term"
+)
+
+// KittyGraphicsSupported returns true if the terminal answers a Kitty graphics
+// protocol query. Terminals without the protocol ignore it.
+//
+// IMPORTANT: MUST BE CALLED BEFORE BUBBLETEA STARTS
+func KittyGraphicsSupported() bool {
+	// a=q checks a 1x1 image without storing it; a supporting terminal
+	// answers \x1b_Gi=31;OK\x1b\\
+	resp := term.Query("\x1b_Gi=31,s=1,v=1,a=q,t=d,f=24;AAAA\x1b\\")
+	return strings.Contains(string(resp), "i=31;OK")
+}
@@ -1,6 +1,7 @@
 package player
 
 import (
+	"fmt"
 	"image/color"
 	_ "image/jpeg"
 	"io"
@@ -8,31 +9,56 @@ import (
 	"os"
 	"sync"
 	"sync/atomic"
+	"time"
+
+	"github.com/gopxl/beep/v2/speaker"
 )
 
 // AVPlayer implements the Player interface using FFmpeg
 type AVPlayer struct {
-	renderer *KittyRenderer
+	renderer     Renderer
+	rendererName string // "auto" or a registered renderer, see renderers.go
 
 	output      io.Writer
 	width       int
 	height      int
 	useShm      bool
+	syncUpdate  bool
 	retinaScale int         // HiDPI pixel-density factor (2 on macOS retina, else 1)
 	border      color.Color // nil = none
+	audioFade   time.Duration
+	normalize   bool      // loudness normalization, see SetNormalizeAudio
+	loudness    *loudness // normalization state shared by every session
+	frameBuffer int       // decoded frames buffered ahead of rendering
+	fitMode     string    // FitContain, FitCover or FitStretch
+	syncThresh  time.Duration
+	syncMode    string // SyncDrop, SyncSleep or SyncAdaptive
 
 	playing        atomic.Bool
 	paused         atomic.Bool
 	muted          atomic.Bool
 	needsRedrawVid atomic.Bool
+	holdFrame      atomic.Bool  // see nextVideoID
+	videoID        atomic.Int64 // image ID of the video frame on screen
+	loops          atomic.Int64 // times the current reel has played through
+	loopCount      atomic.Int64 // plays before playback finishes, 0 = loop forever
 	volume         atomic.Value // float64, 0.0–1.0
+	brightness     atomic.Value // float64, 0.0–2.0 video brightness gain
 
 	playMu   sync.Mutex
 	configMu sync.Mutex
 
+	// finished gets one value when the current play ends, see Finished
+	finishedMu sync.Mutex
+	finished   chan bool
+
 	sessionMu sync.Mutex
 	session   *playSession
 
+	// warm is the next reel's pipeline opened by Prewarm, if any
+	warmMu sync.Mutex
+	warm   *warmPipeline
+
 	gifSlotsMu sync.Mutex
 	gifSlots   []GifSlot
 
@@ -47,25 +73,48 @@ func (p *AVPlayer) sessionConfig() sessionConfig {
 	p.configMu.Lock()
 	defer p.configMu.Unlock()
 
-	// first time, make a new renderer
-	if p.renderer == nil {
-		p.renderer = NewKittyRenderer(p.output)
+	var norm *loudness
+	if p.normalize {
+		norm = p.loudness
 	}
-
 	return sessionConfig{
 		width:       p.width,
 		height:      p.height,
-		renderer:    p.renderer,
+		renderer:    p.rendererLocked(),
 		muted:       p.muted.Load(),
 		volume:      p.volume.Load().(float64),
 		useShm:      p.useShm,
+		syncUpdate:  p.syncUpdate,
 		videoRow:    p.videoRow,
 		videoCol:    p.videoCol,
 		retinaScale: p.retinaScale,
 		border:      p.border,
+		audioFade:   p.audioFade,
+		frameBuffer: p.frameBuffer,
+		fitMode:     p.fitMode,
+		syncThresh:  p.syncThresh.Seconds(),
+		syncMode:    p.syncMode,
+		loudness:    norm,
 	}
 }
 
+// rendererLocked returns the renderer, making it the first time. SetRenderer
+// already validated and resolved the name; if it was never called the name is
+// still "auto", and probing now would race bubbletea for stdin, so that gets
+// the kitty renderer. Must hold configMu.
+func (p *AVPlayer) rendererLocked() Renderer {
+	if p.renderer == nil {
+		var r Renderer = NewKittyRenderer(p.output)
+		if p.rendererName != "auto" {
+			if named, err := NewRenderer(p.rendererName, p.output); err == nil {
+				r = named
+			}
+		}
+		p.renderer = r
+	}
+	return p.renderer
+}
+
 func (p *AVPlayer) setSession(s *playSession) {
 	p.sessionMu.Lock()
 	defer p.sessionMu.Unlock()
@@ -95,10 +144,15 @@ func (p *AVPlayer) withSession(fn func(*playSession)) {
 // NewAVPlayer creates a new FFmpeg-based player
 func NewAVPlayer() *AVPlayer {
 	p := &AVPlayer{
-		output:      os.Stdout,
-		retinaScale: 1,
+		output:       os.Stdout,
+		rendererName: "auto",
+		retinaScale:  1,
+		frameBuffer:  3,
+		loudness:     &loudness{},
 	}
 	p.volume.Store(float64(1))
+	p.brightness.Store(float64(1))
+	p.videoID.Store(VideoImageID)
 	return p
 }
 
@@ -113,8 +167,8 @@ func (p *AVPlayer) SetOutput(w io.Writer) {
 	}
 }
 
-// SetSize sets the maximum video display dimensions in pixels.
-// The video will be scaled to fit within these bounds while maintaining aspect ratio.
+// SetSize sets the video's bounding box in pixels. How the video fills it
+// depends on the fit mode, see SetFitMode.
 func (p *AVPlayer) SetSize(width, height int) {
 	p.configMu.Lock()
 	defer p.configMu.Unlock()
@@ -127,10 +181,8 @@ func (p *AVPlayer) SetSize(width, height int) {
 			return
 		}
 
-		// Calculate scaled size maintaining aspect ratio
 		srcW, srcH := s.video.SourceSize()
-		dstW, dstH := fitSize(srcW, srcH, width, height)
-		s.video.SetSize(dstW, dstH)
+		s.video.SetCroppedSize(fitFrame(srcW, srcH, width, height, p.fitMode))
 
 		// Update renderer terminal metrics
 		if s.renderer != nil {
@@ -151,17 +203,58 @@ func (p *AVPlayer) SetVideoPosition(row, col int) {
 	p.configMu.Unlock()
 
 	p.withSession(func(s *playSession) {
+		s.layoutMu.Lock()
 		s.videoRow = row
 		s.videoCol = col
+		s.layoutMu.Unlock()
+	})
+}
+
+// Resize is SetSize and SetVideoPosition in one step, for terminal resizes.
+// row and col are the top-left of the bounding box; the centering offset for
+// the new size is added here. The active session switches size and position
+// together, so no frame lands at a mix of the old and new layout.
+func (p *AVPlayer) Resize(width, height, row, col int) {
+	p.configMu.Lock()
+	defer p.configMu.Unlock()
+
+	p.width = width
+	p.height = height
+	p.videoRow = row
+	p.videoCol = col
+
+	p.withSession(func(s *playSession) {
+		if s.video == nil {
+			return
+		}
+
+		srcW, srcH := s.video.SourceSize()
+		scaleW, scaleH, dstW, dstH := fitFrame(srcW, srcH, width, height, p.fitMode)
+		rowOff, colOff := centerOffset(width, height, dstW, dstH)
+		p.videoRow = row + rowOff
+		p.videoCol = col + colOff
+
+		s.layoutMu.Lock()
+		s.video.SetCroppedSize(scaleW, scaleH, dstW, dstH)
+		s.videoRow = p.videoRow
+		s.videoCol = p.videoCol
+		s.layoutMu.Unlock()
+
+		if s.renderer != nil {
+			if cols, rows, termW, termH, err := GetTerminalSize(); err == nil && cols > 0 && rows > 0 {
+				s.renderer.SetTerminalSize(cols, rows, termW, termH)
+			}
+		}
 	})
 }
 
 // VideoCenterOffset returns the (row, col) offset needed to center the actual video
 // content within the 9:16 bounding box. Most reel videos are exactly 9:16, so the
 // offset is (0, 0). But when a video has a different aspect ratio (e.g. 1:1 or 16:9),
-// it gets scaled to fit inside the bounding box.
+// FitContain scales it to fit inside the bounding box.
 //
-// Returns (0, 0) if there is no active session or the video perfectly fills the box.
+// Returns (0, 0) if there is no active session or the video fills the box, which
+// it always does under FitCover and FitStretch.
 func (p *AVPlayer) VideoCenterOffset() (rowOffset, colOffset int) {
 	p.withSession(func(s *playSession) {
 		if s.video == nil {
@@ -170,49 +263,89 @@ func (p *AVPlayer) VideoCenterOffset() (rowOffset, colOffset int) {
 		srcW, srcH := s.video.SourceSize()
 
 		p.configMu.Lock()
-		width, height := p.width, p.height
+		width, height, fitMode := p.width, p.height, p.fitMode
 		p.configMu.Unlock()
 
-		dstW, dstH := fitSize(srcW, srcH, width, height)
-
-		cols, rows, termW, termH, err := GetTerminalSize()
-		if err != nil || cols == 0 || rows == 0 {
-			return
-		}
-		cellW := termW / cols
-		cellH := termH / rows
-		if cellW > 0 {
-			colOffset = (width - dstW) / 2 / cellW
-		}
-		if cellH > 0 {
-			rowOffset = (height - dstH) / 2 / cellH
-		}
+		_, _, dstW, dstH := fitFrame(srcW, srcH, width, height, fitMode)
+		rowOffset, colOffset = centerOffset(width, height, dstW, dstH)
 	})
 	return
 }
 
+// centerOffset converts the pixel gap between a dstW x dstH video and its
+// width x height bounding box into the cell offset that centers it.
+func centerOffset(width, height, dstW, dstH int) (rowOffset, colOffset int) {
+	cols, rows, termW, termH, err := GetTerminalSize()
+	if err != nil || cols == 0 || rows == 0 {
+		return 0, 0
+	}
+	cellW := termW / cols
+	cellH := termH / rows
+	if cellW > 0 {
+		colOffset = (width - dstW) / 2 / cellW
+	}
+	if cellH > 0 {
+		rowOffset = (height - dstH) / 2 / cellH
+	}
+	return rowOffset, colOffset
+}
+
 // Play initializes a play session and starts the render loop in a background goroutine.
-// It returns once the session is ready (or on error). The render loop runs until Stop is called.
+// It returns once the session is ready (or on error). The render loop runs until Stop is called,
+// or until the reel has played loop_count times (see SetLoopCount and Finished).
 func (p *AVPlayer) Play(videoPath string) error {
+	return p.PlayFrom(videoPath, 0)
+}
+
+// PlayFrom is Play starting at start seconds instead of the beginning. Only
+// the first pass starts there; later loops start from 0.
+func (p *AVPlayer) PlayFrom(videoPath string, start float64) error {
 	p.playMu.Lock()
 
 	p.playing.Store(true)
 	p.paused.Store(false)
+	p.loops.Store(0)
 
+	started := time.Now()
 	session, err := p.initSession(videoPath)
 	if err != nil {
 		p.playMu.Unlock()
 		return err
 	}
+	session.started = started
+	if start > 0 {
+		// picked up by the demux loop before its first read
+		session.Seek(start)
+	}
+
+	finished := make(chan bool, 1)
+	p.finishedMu.Lock()
+	p.finished = finished
+	p.finishedMu.Unlock()
 
-	go p.playbackLoop(videoPath, session)
+	go p.playbackLoop(videoPath, session, finished)
 	return nil
 }
 
+// Finished returns a channel that receives once when the current play ends:
+// true if the reel played through loop_count times, false if it was stopped
+// or failed to restart. It's nil before the first Play.
+func (p *AVPlayer) Finished() <-chan bool {
+	p.finishedMu.Lock()
+	defer p.finishedMu.Unlock()
+	return p.finished
+}
+
+// SetLoopCount sets how many times a reel plays before playback finishes on
+// its own. 0 loops until Stop. Takes effect on the current reel too.
+func (p *AVPlayer) SetLoopCount(n int) {
+	p.loopCount.Store(int64(max(n, 0)))
+}
+
 // initSession creates a configured play session ready for rendering.
 func (p *AVPlayer) initSession(videoPath string) (*playSession, error) {
 	cfg := p.sessionConfig()
-	session, err := newPlaySession(videoPath, cfg)
+	session, err := newPlaySession(videoPath, cfg, p.takeWarm(videoPath))
 	if err != nil {
 		return nil, err
 	}
@@ -236,10 +369,15 @@ func (p *AVPlayer) initSession(videoPath string) (*playSession, error) {
 	return session, nil
 }
 
-// playbackLoop runs the current session, then loops by creating new sessions.
-// Holds playMu for its entire duration so Close() can wait for playback to finish.
-func (p *AVPlayer) playbackLoop(videoPath string, session *playSession) {
-	defer p.playMu.Unlock()
+// playbackLoop runs the current session, then loops by creating new sessions
+// until Stop or loop_count plays. Holds playMu for its entire duration so
+// Close() can wait for playback to finish. finished reports which it was.
+func (p *AVPlayer) playbackLoop(videoPath string, session *playSession, finished chan<- bool) {
+	complete := false
+	defer func() {
+		p.playMu.Unlock()
+		finished <- complete
+	}()
 
 	for {
 		session.run(p)
@@ -249,6 +387,13 @@ func (p *AVPlayer) playbackLoop(videoPath string, session *playSession) {
 		if !p.playing.Load() {
 			return
 		}
+		p.loops.Add(1)
+		if n := p.loopCount.Load(); n > 0 && p.loops.Load() >= n {
+			// the last frame stays up until the next reel replaces it
+			p.playing.Store(false)
+			complete = true
+			return
+		}
 
 		var err error
 		session, err = p.initSession(videoPath)
@@ -258,6 +403,32 @@ func (p *AVPlayer) playbackLoop(videoPath string, session *playSession) {
 	}
 }
 
+// SetHoldFrame keeps each video frame, including the last frame of the
+// previous reel, on screen until its replacement has been placed.
+func (p *AVPlayer) SetHoldFrame(hold bool) {
+	p.holdFrame.Store(hold)
+}
+
+// nextVideoID picks the image ID for the next video frame. RenderImage
+// deletes an ID's old image before transmitting the new one, which leaves the
+// spot blank while a large frame transmits (most visibly when switching
+// reels). With hold_frame the video alternates between two IDs, so the old
+// frame is only removed by Prune after the new one is up.
+func (p *AVPlayer) nextVideoID() int {
+	if !p.holdFrame.Load() {
+		return VideoImageID
+	}
+	if p.videoID.Load() == VideoImageID {
+		return VideoBackID
+	}
+	return VideoImageID
+}
+
+// shownVideoID returns the image ID of the video frame on screen.
+func (p *AVPlayer) shownVideoID() int {
+	return int(p.videoID.Load())
+}
+
 // Stop stops current playback
 func (p *AVPlayer) Stop() {
 	p.playing.Store(false)
@@ -276,6 +447,23 @@ func (p *AVPlayer) Mute() {
 	})
 }
 
+// SetRenderer selects the renderer by name ("auto", "kitty", ...). It must be
+// called before the first Play, and before bubbletea starts since "auto"
+// probes the terminal right away. An unavailable name returns an error and
+// leaves the current choice unchanged.
+func (p *AVPlayer) SetRenderer(name string) error {
+	if name == "" || name == "auto" {
+		name = DetectRenderer()
+	}
+	if _, err := NewRenderer(name, io.Discard); err != nil {
+		return err
+	}
+	p.configMu.Lock()
+	defer p.configMu.Unlock()
+	p.rendererName = name
+	return nil
+}
+
 // SetUseShm enables or disables shared memory transmission for rendering.
 func (p *AVPlayer) SetUseShm(useShm bool) {
 	p.configMu.Lock()
@@ -283,6 +471,16 @@ func (p *AVPlayer) SetUseShm(useShm bool) {
 	p.useShm = useShm
 }
 
+// SetSyncUpdate enables or disables synchronized-update escapes around frames.
+func (p *AVPlayer) SetSyncUpdate(syncUpdate bool) {
+	p.configMu.Lock()
+	defer p.configMu.Unlock()
+	p.syncUpdate = syncUpdate
+	if p.renderer != nil {
+		p.renderer.SetSyncUpdate(syncUpdate)
+	}
+}
+
 // SetRetinaScale sets the pixel-density factor for the video progress bar and border
 func (p *AVPlayer) SetRetinaScale(scale int) {
 	p.configMu.Lock()
@@ -293,6 +491,78 @@ func (p *AVPlayer) SetRetinaScale(scale int) {
 	p.retinaScale = scale
 }
 
+// SetAudioFade sets how long a stopped reel's audio keeps playing while it
+// fades out, overlapping the next reel's load. Zero cuts audio on Stop.
+func (p *AVPlayer) SetAudioFade(d time.Duration) {
+	p.configMu.Lock()
+	defer p.configMu.Unlock()
+	p.audioFade = max(d, 0)
+}
+
+// SetNormalizeAudio turns loudness normalization on or off: the gain follows
+// the running loudness toward a common level, before the volume is applied.
+// The estimate carries over between reels. Applies from the next reel.
+func (p *AVPlayer) SetNormalizeAudio(on bool) {
+	p.configMu.Lock()
+	defer p.configMu.Unlock()
+	p.normalize = on
+}
+
+// A/V sync modes for SetSync: how the render loop corrects video drifting
+// from the audio clock. See playSession.syncFrame.
+const (
+	SyncDrop     = "drop"
+	SyncSleep    = "sleep"
+	SyncAdaptive = "adaptive"
+)
+
+// SetSync sets how far video may drift from the audio clock before it's
+// corrected (0 = SyncThreshold) and how (SyncDrop, SyncSleep or
+// SyncAdaptive; anything else is SyncDrop). Applies from the next reel.
+func (p *AVPlayer) SetSync(threshold time.Duration, mode string) {
+	p.configMu.Lock()
+	defer p.configMu.Unlock()
+	p.syncThresh = max(threshold, 0)
+	switch mode {
+	case SyncSleep, SyncAdaptive:
+		p.syncMode = mode
+	default:
+		p.syncMode = SyncDrop
+	}
+}
+
+// SyncStats returns how far the last frame drawn was from the clock
+// (positive = early) and how many frames the current reel has dropped to
+// catch up, for --debug.
+func (p *AVPlayer) SyncStats() (drift time.Duration, dropped int) {
+	p.withSession(func(s *playSession) {
+		drift, dropped = time.Duration(s.drift.Load()), int(s.dropped.Load())
+	})
+	return drift, dropped
+}
+
+// SetFrameBuffer sets how many decoded frames may queue up ahead of
+// rendering (2 = double buffering, 3 = triple). Applies from the next reel.
+func (p *AVPlayer) SetFrameBuffer(n int) {
+	p.configMu.Lock()
+	defer p.configMu.Unlock()
+	p.frameBuffer = max(n, 1)
+}
+
+// SetFitMode sets how a video that isn't the bounding box's aspect ratio
+// fills it: FitContain, FitCover or FitStretch. Anything else is FitContain.
+// Applies from the next reel.
+func (p *AVPlayer) SetFitMode(mode string) {
+	p.configMu.Lock()
+	defer p.configMu.Unlock()
+	switch mode {
+	case FitCover, FitStretch:
+		p.fitMode = mode
+	default:
+		p.fitMode = FitContain
+	}
+}
+
 // SetVolume sets the volume (0.0–1.0)
 func (p *AVPlayer) SetVolume(vol float64) {
 	p.volume.Store(vol)
@@ -308,6 +578,13 @@ func (p *AVPlayer) Volume() float64 {
 	return p.volume.Load().(float64)
 }
 
+// SetBrightness scales the video's brightness (1 = unchanged, 0 = black, up
+// to 2 to brighten, clipping at white). Only the video is affected; the
+// progress bar and border keep their colors.
+func (p *AVPlayer) SetBrightness(b float64) {
+	p.brightness.Store(min(max(b, 0), 2))
+}
+
 // SetBorder sets the outline color drawn on the video's top, left, and right edges.
 func (p *AVPlayer) SetBorder(c color.Color) {
 	p.configMu.Lock()
@@ -360,12 +637,53 @@ func (p *AVPlayer) Skip(seconds float64) {
 	})
 }
 
+// StepFrame draws the next frame while paused and stays paused. Does nothing
+// while playing.
+func (p *AVPlayer) StepFrame() {
+	if !p.paused.Load() {
+		return
+	}
+	p.withSession(func(s *playSession) {
+		select {
+		case s.stepCh <- struct{}{}:
+		default:
+		}
+	})
+}
+
+// Loops returns how many times the current reel has played to the end and
+// started over.
+func (p *AVPlayer) Loops() int {
+	return int(p.loops.Load())
+}
+
+// Progress returns the position of the last drawn frame and the reel's
+// duration, in seconds. Both are 0 when nothing is playing.
+func (p *AVPlayer) Progress() (pos, dur float64) {
+	p.withSession(func(s *playSession) {
+		pos = math.Float64frombits(s.shownPTS.Load())
+		dur = s.demuxer.Duration()
+	})
+	return pos, dur
+}
+
 // RedrawVideo signals the render loop to advance one frame while paused,
 // picking up any layout changes (position, size, overlays).
 func (p *AVPlayer) RedrawVideo() {
 	p.needsRedrawVid.Store(true)
 }
 
+// AudioPeak returns the current reel's peak audio level (0-1) since the last
+// call. ok is false if the reel has no audio stream or nothing is playing.
+func (p *AVPlayer) AudioPeak() (level float64, ok bool) {
+	p.withSession(func(s *playSession) {
+		if s.audio != nil {
+			level, ok = s.audio.PeakLevel(), true
+		}
+	})
+	return level, ok
+}
+
 // IsMuted returns current mute state
 func (p *AVPlayer) IsMuted() bool {
 	return p.muted.Load()
@@ -404,7 +722,7 @@ func (p *AVPlayer) ClearGifs() {
 		r := p.renderer
 		p.configMu.Unlock()
 		if r != nil {
-			r.Prune(map[int]bool{VideoImageID: true})
+			r.Prune(map[int]bool{p.shownVideoID(): true})
 		}
 	}
 }
@@ -441,11 +759,49 @@ func (p *AVPlayer) ClearImages() {
 		r := p.renderer
 		p.configMu.Unlock()
 		if r != nil {
-			r.Prune(map[int]bool{VideoImageID: true})
+			r.Prune(map[int]bool{p.shownVideoID(): true})
 		}
 	}
 }
 
+// RenderStill draws images with no video, replacing everything else on
+// screen (the grid overview). There's no render loop to redraw them, so call
+// it again whenever the slots change. Does nothing while playing.
+func (p *AVPlayer) RenderStill(slots []ImageSlot) error {
+	if p.playing.Load() {
+		return nil
+	}
+
+	// wait out a stopped session that's still tearing down
+	p.playMu.Lock()
+	defer p.playMu.Unlock()
+
+	p.configMu.Lock()
+	r := p.rendererLocked()
+	if cols, rows, termW, termH, err := GetTerminalSize(); err == nil && cols > 0 && rows > 0 {
+		r.SetTerminalSize(cols, rows, termW, termH)
+	}
+	p.configMu.Unlock()
+
+	r.BeginSync()
+	defer r.EndSync()
+
+	keep := make(map[int]bool, len(slots))
+	for i, slot := range slots {
+		pic, w, h := slot.Img.Snapshot()
+		if len(pic) == 0 || w == 0 || h == 0 {
+			continue
+		}
+		id := ThumbImageID + i
+		keep[id] = true
+		if err := r.RenderImage(pic, 32, w, h, id, slot.Row, slot.Col); err != nil {
+			return fmt.Errorf("still image render error: %w", err)
+		}
+	}
+	r.Prune(keep)
+	return nil
+}
+
 // Close releases all resources.
 // Waits for the Play goroutine to finish before clearing terminal images,
 // preventing a race where frames render after cleanup.
@@ -462,10 +818,16 @@ func (p *AVPlayer) Close() {
 	p.playMu.Lock()
 	p.playMu.Unlock()
 
+	// Cut any audio tail that is still fading out
+	speaker.Clear()
+
+	p.dropWarm()
+
 	p.configMu.Lock()
 	defer p.configMu.Unlock()
 
 	if p.renderer != nil {
+		p.renderer.DeleteAllImages()
 		p.renderer.CleanupShm()
 		p.renderer = nil
 	}
@@ -0,0 +1,137 @@
+package player
+
+import "fmt"
+
+// maxWarmPackets bounds how far Prewarm reads looking for the first frame. It
+// stays under the session's audio packet buffer so handing the read-ahead
+// audio over never blocks.
+const maxWarmPackets = 64
+
+// warmPipeline is a demuxer and video decoder opened ahead of playback so
+// Play can skip probing the container and opening the codec.
+type warmPipeline struct {
+	path    string
+	demuxer *Demuxer
+	video   *VideoDecoder
+
+	// first is the reel's first video frame, decoded ahead so it's drawn as
+	// soon as playback starts. audio is the packets read on the way to it,
+	// which the session plays before reading on.
+	first *Frame
+	audio []*audioPacket
+}
+
+func (w *warmPipeline) close() {
+	for _, apkt := range w.audio {
+		apkt.pkt.Free()
+	}
+	w.audio = nil
+	w.video.Close()
+	w.demuxer.Close()
+}
+
+// decodeFirstFrame reads until the decoder puts out the first video frame.
+// The video packets read are consumed by the decoder, so a session adopting
+// the pipeline carries on right after them; anything else is kept in audio.
+// On failure the pipeline is mid-stream and must be closed, not used.
+func (w *warmPipeline) decodeFirstFrame() error {
+	for range maxWarmPackets {
+		pkt, isVideo, err := w.demuxer.ReadPacket()
+		if err != nil {
+			return err
+		}
+		if !isVideo {
+			w.audio = append(w.audio, &audioPacket{pkt: pkt, pts: w.demuxer.PTSToSeconds(pkt.Pts(), false)})
+			continue
+		}
+		frame, err := w.video.DecodePacket(pkt)
+		pkt.Free()
+		if err != nil {
+			return err
+		}
+		if frame != nil {
+			w.first = frame
+			return nil
+		}
+	}
+	return fmt.Errorf("no video frame in the first %d packets", maxWarmPackets)
+}
+
+// Prewarm opens the demuxer and video decoder for videoPath, primes the
+// scaling context at the current size and decodes the first frame, so a
+// following Play(videoPath) starts without that setup and draws its first
+// frame at once. Only one pipeline is kept warm; prewarming another path
+// releases the previous one.
+func (p *AVPlayer) Prewarm(videoPath string) error {
+	p.warmMu.Lock()
+	if p.warm != nil && p.warm.path == videoPath {
+		p.warmMu.Unlock()
+		return nil
+	}
+	p.warmMu.Unlock()
+
+	demuxer, err := NewDemuxer(videoPath)
+	if err != nil {
+		return err
+	}
+	video, err := NewVideoDecoder(demuxer.VideoCodecParameters(), demuxer.VideoTimeBase())
+	if err != nil {
+		demuxer.Close()
+		return err
+	}
+
+	p.configMu.Lock()
+	width, height, fitMode := p.width, p.height, p.fitMode
+	p.configMu.Unlock()
+
+	srcW, srcH := video.SourceSize()
+	video.SetCroppedSize(fitFrame(srcW, srcH, width, height, fitMode))
+	if err := video.prime(demuxer.VideoCodecParameters().PixelFormat()); err != nil {
+		video.Close()
+		demuxer.Close()
+		return err
+	}
+
+	warm := &warmPipeline{path: videoPath, demuxer: demuxer, video: video}
+	if err := warm.decodeFirstFrame(); err != nil {
+		warm.close()
+		return err
+	}
+
+	p.warmMu.Lock()
+	old := p.warm
+	p.warm = warm
+	p.warmMu.Unlock()
+
+	if old != nil {
+		old.close()
+	}
+	return nil
+}
+
+// takeWarm hands over the warm pipeline if it was opened for videoPath.
+// A pipeline for another path stays warm: a looping reel re-inits its own
+// session while the next reel is already prewarmed.
+func (p *AVPlayer) takeWarm(videoPath string) *warmPipeline {
+	p.warmMu.Lock()
+	defer p.warmMu.Unlock()
+
+	w := p.warm
+	if w == nil || w.path != videoPath {
+		return nil
+	}
+	p.warm = nil
+	return w
+}
+
+// dropWarm releases the warm pipeline, if any.
+func (p *AVPlayer) dropWarm() {
+	p.warmMu.Lock()
+	w := p.warm
+	p.warm = nil
+	p.warmMu.Unlock()
+
+	if w != nil {
+		w.close()
+	}
+}
@@ -27,6 +27,10 @@ type KittyRenderer struct {
 	useShm   bool // true when terminal supports t=s
 	shmIndex int  // monotonically increasing counter for unique shm names
 
+	// syncUpdate gates the synchronized-update escapes in BeginSync/EndSync;
+	// terminals without mode 2026 print them as garbage
+	syncUpdate bool
+
 	renderCache map[int]renderCacheEntry
 }
 
@@ -52,6 +56,13 @@ func (r *KittyRenderer) SetUseShm(useShm bool) {
 	r.useShm = useShm
 }
 
+// SetSyncUpdate enables or disables the synchronized-update escapes.
+func (r *KittyRenderer) SetSyncUpdate(syncUpdate bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.syncUpdate = syncUpdate
+}
+
 // SetOutput changes the output writer
 func (r *KittyRenderer) SetOutput(w io.Writer) {
 	r.mu.Lock()
@@ -125,6 +136,9 @@ func (r *KittyRenderer) RenderImage(data []byte, format, width, height, id, row,
 func (r *KittyRenderer) BeginSync() {
 	r.mu.Lock()
 	defer r.mu.Unlock()
+	if !r.syncUpdate {
+		return
+	}
 	r.out.Write([]byte("\x1b[?2026h"))
 }
 
@@ -132,6 +146,9 @@ func (r *KittyRenderer) BeginSync() {
 func (r *KittyRenderer) EndSync() {
 	r.mu.Lock()
 	defer r.mu.Unlock()
+	if !r.syncUpdate {
+		return
+	}
 	r.out.Write([]byte("\x1b[?2026l"))
 }
 
@@ -148,6 +165,17 @@ func (r *KittyRenderer) Prune(keep map[int]bool) {
 	}
 }
 
+// DeleteAllImages deletes every image and its data from the terminal, not just
+// the IDs in the render cache: some terminals keep images placed on the alt
+// screen after switching back to the normal one.
+func (r *KittyRenderer) DeleteAllImages() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.renderCache = nil
+	r.out.Write([]byte("\x1b_Ga=d,d=A,q=2\x1b\\"))
+}
+
 // writeImageDirect encodes pixel data as base64 and writes it in chunks using direct transmission (t=d).
 // format is 24 (RGB) or 32 (RGBA). id is the kitty image ID.
 func (r *KittyRenderer) writeImageDirect(buf *bytes.Buffer, data []byte, format, width, height, id int) {
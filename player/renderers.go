@@ -0,0 +1,63 @@
+package player
+
+import (
+	"fmt"
+	"io"
+)
+
+// rendererEntry is a registered renderer: detect reports whether the current
+// terminal can display it, new constructs it writing to out. detect probes the
+// terminal, so it must run before bubbletea starts.
+type rendererEntry struct {
+	name   string
+	detect func() bool
+	new    func(out io.Writer) Renderer
+}
+
+// renderers is the detection chain "auto" walks, in order of preference.
+var renderers = []rendererEntry{
+	{
+		name:   "kitty",
+		detect: KittyGraphicsSupported,
+		new:    func(out io.Writer) Renderer { return NewKittyRenderer(out) },
+	},
+	{
+		name:   "sixel",
+		detect: SixelSupported,
+		new:    func(out io.Writer) Renderer { return NewSixelRenderer(out) },
+	},
+	{
+		name: "halfblock",
+		// plain text with truecolor escapes: works where nothing else does
+		detect: func() bool { return true },
+		new:    func(out io.Writer) Renderer { return NewHalfBlockRenderer(out) },
+	},
+}
+
+// DetectRenderer returns the name of the first renderer in the detection
+// chain the terminal supports. The chain ends in halfblock, so the "kitty"
+// fallback is only reached if that's ever removed.
+//
+// IMPORTANT: MUST BE CALLED BEFORE BUBBLETEA STARTS
+func DetectRenderer() string {
+	for _, r := range renderers {
+		if r.detect() {
+			return r.name
+		}
+	}
+	return "kitty"
+}
+
+// NewRenderer constructs the renderer called name writing to out. "auto" (or
+// "") picks one with DetectRenderer.
+func NewRenderer(name string, out io.Writer) (Renderer, error) {
+	if name == "" || name == "auto" {
+		name = DetectRenderer()
+	}
+	for _, r := range renderers {
+		if r.name == name {
+			return r.new(out), nil
+		}
+	}
+	return nil, fmt.Errorf("unknown renderer %q", name)
+}
@@ -3,6 +3,7 @@ package player
 import (
 	"fmt"
 	"image/color"
+	"log"
 	"math"
 	"runtime"
 	"sync"
@@ -24,13 +25,20 @@ type playSession struct {
 	demuxer  *Demuxer
 	audio    *AudioPlayer
 	video    *VideoDecoder
-	renderer *KittyRenderer
+	renderer Renderer
 
-	// Cell positions for image placement (1-indexed)
+	// Cell positions for image placement (1-indexed). layoutMu keeps them in
+	// step with the decoder's output size so a resize never draws a frame at
+	// the new size in the old spot.
+	layoutMu           sync.Mutex
 	videoRow, videoCol int
 	retinaScale        int
 	border             *[3]uint8 // nil = none
 
+	// brightLUT maps a channel value to its value at brightness brightLevel
+	brightLUT   [256]byte
+	brightLevel float64
+
 	audioPktCh chan *audioPacket
 	videoPktCh chan *astiav.Packet
 
@@ -42,12 +50,45 @@ type playSession struct {
 	wallFallbackStartTime time.Time // wall-clock origin for no-audio sync
 	wallFallbackStartPTS  float64   // PTS offset at wall-clock origin
 
+	// A/V sync settings (see SetSync). renderTime is a running average of
+	// how long drawing a frame takes, which SyncAdaptive allows for.
+	syncThreshold float64
+	syncMode      string
+	renderTime    float64
+
+	// drift is the last frame's distance from the clock in nanoseconds
+	// (positive = early) and dropped counts frames skipped to catch up,
+	// for SyncStats
+	drift   atomic.Int64
+	dropped atomic.Int64
+
 	stopCh   chan struct{}
 	stopOnce sync.Once
 
+	// frameCh carries decoded frames from decodeLoop to videoRenderLoop;
+	// decodeErr is set by decodeLoop before it closes frameCh
+	frameCh   chan *Frame
+	decodeErr error
+
 	seekCh  chan float64
 	seekGen atomic.Int64
 	seekPTS atomic.Uint64
+
+	// stepCh asks the paused render loop to draw exactly one more frame
+	stepCh chan struct{}
+
+	// shownPTS is the PTS (float64 bits) of the last frame drawn
+	shownPTS atomic.Uint64
+
+	// firstFrame and preAudio are taken over from a warm pipeline that
+	// already decoded the reel's first frame (see warmPipeline)
+	firstFrame *Frame
+	preAudio   []*audioPacket
+
+	// started is when Play began setting up this session; the first frame
+	// drawn logs how long that took. Zero for loop restarts.
+	started time.Time
+	warm    bool
 }
 
 type audioPacket struct {
@@ -61,39 +102,61 @@ type sessionConfig struct {
 	videoRow    int
 	videoCol    int
 	retinaScale int
-	renderer    *KittyRenderer
+	renderer    Renderer
 	muted       bool
 	volume      float64
 	useShm      bool
+	syncUpdate  bool
 	border      color.Color
+	audioFade   time.Duration
+	loudness    *loudness // nil = no normalization
+	syncThresh  float64   // seconds
+	syncMode    string
+	frameBuffer int
+	fitMode     string
 }
 
-func newPlaySession(url string, cfg sessionConfig) (*playSession, error) {
-	demuxer, err := NewDemuxer(url)
-	if err != nil {
-		return nil, fmt.Errorf("failed to open media: %w", err)
-	}
+// newPlaySession opens url for playback. warm, if non-nil, is a pipeline
+// already opened for url by Prewarm and is used instead of opening a new one.
+func newPlaySession(url string, cfg sessionConfig, warm *warmPipeline) (*playSession, error) {
+	var demuxer *Demuxer
+	var video *VideoDecoder
+	var first *Frame
+	var preAudio []*audioPacket
+	if warm != nil {
+		demuxer, video = warm.demuxer, warm.video
+		first, preAudio = warm.first, warm.audio
+	} else {
+		var err error
+		demuxer, err = NewDemuxer(url)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open media: %w", err)
+		}
 
-	video, err := NewVideoDecoder(
-		demuxer.VideoCodecParameters(),
-		demuxer.VideoTimeBase(),
-	)
-	if err != nil {
-		demuxer.Close()
-		return nil, fmt.Errorf("failed to create video decoder: %w", err)
+		video, err = NewVideoDecoder(
+			demuxer.VideoCodecParameters(),
+			demuxer.VideoTimeBase(),
+		)
+		if err != nil {
+			demuxer.Close()
+			return nil, fmt.Errorf("failed to create video decoder: %w", err)
+		}
 	}
 
 	srcW, srcH := video.SourceSize()
-	dstW, dstH := fitSize(srcW, srcH, cfg.width, cfg.height)
-	video.SetSize(dstW, dstH)
+	scaleW, scaleH, dstW, dstH := fitFrame(srcW, srcH, cfg.width, cfg.height, cfg.fitMode)
+	video.SetCroppedSize(scaleW, scaleH, dstW, dstH)
 
 	var audio *AudioPlayer
 	if demuxer.HasAudio() {
+		var err error
 		audio, err = NewAudioPlayer(demuxer.AudioCodecParameters())
 		if err != nil {
 			audio = nil
 		} else {
 			audio.SetVolume(cfg.volume)
+			audio.SetFadeOut(cfg.audioFade)
+			audio.SetLoudness(cfg.loudness)
 			if cfg.muted {
 				audio.Mute()
 			}
@@ -107,6 +170,7 @@ func newPlaySession(url string, cfg sessionConfig) (*playSession, error) {
 			renderer.SetTerminalSize(cols, rows, termW, termH)
 		}
 		renderer.SetUseShm(cfg.useShm)
+		renderer.SetSyncUpdate(cfg.syncUpdate)
 	}
 
 	session := &playSession{
@@ -119,10 +183,25 @@ func newPlaySession(url string, cfg sessionConfig) (*playSession, error) {
 		retinaScale: cfg.retinaScale,
 		stopCh:      make(chan struct{}),
 		seekCh:      make(chan float64, 1),
+		stepCh:      make(chan struct{}, 1),
+		frameCh:     make(chan *Frame, max(cfg.frameBuffer, 1)),
 		videoPktCh:  make(chan *astiav.Packet, 60),
+
+		syncThreshold: cfg.syncThresh,
+		syncMode:      cfg.syncMode,
 	}
 	if audio != nil {
 		session.audioPktCh = make(chan *audioPacket, 128)
+		session.preAudio = preAudio
+	} else {
+		for _, apkt := range preAudio {
+			apkt.pkt.Free()
+		}
+	}
+	// a first frame scaled for another size would only be dropped
+	if first != nil && first.Width == dstW && first.Height == dstH {
+		session.firstFrame = first
+		session.warm = true
 	}
 	session.seekGen.Store(0)
 	session.seekPTS.Store(0)
@@ -147,11 +226,15 @@ func (s *playSession) run(p *AVPlayer) error {
 		s.wallFallbackStartPTS = 0
 	}
 
-	demuxWg.Add(1)
+	demuxWg.Add(2)
 	go func() {
 		defer demuxWg.Done()
 		s.demuxLoop(p)
 	}()
+	go func() {
+		defer demuxWg.Done()
+		s.decodeLoop(p)
+	}()
 
 	err := s.videoRenderLoop(p)
 
@@ -163,6 +246,7 @@ func (s *playSession) run(p *AVPlayer) error {
 	}
 	audioWg.Wait()
 
+	log.Printf("playback: %d frames dropped, last drift %v", s.dropped.Load(), time.Duration(s.drift.Load()).Round(time.Millisecond))
 	return err
 }
 
@@ -287,6 +371,13 @@ func (s *playSession) demuxLoop(p *AVPlayer) {
 
 	defer close(s.videoPktCh)
 
+	// audio Prewarm read ahead while decoding the first frame; it fits in
+	// audioPktCh (see maxWarmPackets)
+	for _, apkt := range s.preAudio {
+		s.audioPktCh <- apkt
+	}
+	s.preAudio = nil
+
 	for p.playing.Load() {
 		select {
 		case <-s.stopCh:
@@ -299,8 +390,8 @@ func (s *playSession) demuxLoop(p *AVPlayer) {
 
 		pkt, isVideo, err := s.demuxer.ReadPacket()
 		if err != nil {
-			if err == astiav.ErrEof {
-				return
+			if err != astiav.ErrEof {
+				log.Printf("demux: %v", err)
 			}
 			return
 		}
@@ -338,7 +429,57 @@ func (s *playSession) demuxLoop(p *AVPlayer) {
 	}
 }
 
-// videoRenderLoop processes video packets and renders frames.
+// decodeLoop decodes video packets into frameCh so a slow render (e.g. a
+// large kitty transmit) doesn't hold up decoding. frameCh's capacity bounds
+// how far decoding runs ahead of the render loop.
+// Frames decoded before a seek are left in frameCh; the render loop's seek
+// phases drop them.
+func (s *playSession) decodeLoop(p *AVPlayer) {
+	// decoded by Prewarm; frameCh is empty and has room for it
+	if s.firstFrame != nil {
+		s.frameCh <- s.firstFrame
+		s.firstFrame = nil
+	}
+
+decode:
+	for pkt := range s.videoPktCh {
+		if pkt == nil {
+			continue
+		}
+
+		if !p.playing.Load() {
+			pkt.Free()
+			continue
+		}
+
+		frame, err := s.video.DecodePacket(pkt)
+		pkt.Free()
+
+		if err != nil {
+			s.decodeErr = fmt.Errorf("video decode error: %w", err)
+			break
+		}
+		if frame == nil {
+			continue
+		}
+
+		select {
+		case s.frameCh <- frame:
+		case <-s.stopCh:
+			break decode
+		}
+	}
+
+	close(s.frameCh)
+
+	// keep the demux loop from blocking on a decoder that has stopped early;
+	// it closes videoPktCh once it returns
+	for pkt := range s.videoPktCh {
+		pkt.Free()
+	}
+}
+
+// videoRenderLoop renders decoded frames from decodeLoop.
 func (s *playSession) videoRenderLoop(p *AVPlayer) error {
 	// Since avcodec_flush_buffers is not exposed by go-astiav, we handle
 	// stale packets from ffmpeg using a state machine.
@@ -367,6 +508,10 @@ func (s *playSession) videoRenderLoop(p *AVPlayer) error {
 	var seekState seekPhase = seekPhaseNone
 	var seekTarget float64 = 0
 
+	// stepping is set by a frame-step request and holds the render loop out of
+	// the pause loop until one frame has been drawn
+	stepping := false
+
 	checkSeek := func() {
 		if gen := s.seekGen.Load(); gen != lastSeekGen {
 			lastSeekGen = gen
@@ -375,28 +520,28 @@ func (s *playSession) videoRenderLoop(p *AVPlayer) error {
 		}
 	}
 
-	for pkt := range s.videoPktCh {
-		if pkt == nil {
-			continue
-		}
-
+	for frame := range s.frameCh {
 		if !p.playing.Load() {
-			pkt.Free()
 			continue
 		}
 
 		checkSeek()
 
-		redraw := false
-		for p.paused.Load() {
+		for p.paused.Load() && !stepping {
 			if p.needsRedrawVid.CompareAndSwap(true, false) {
-				redraw = true
 				break
 			}
 
+			select {
+			case <-s.stepCh:
+				stepping = true
+				continue
+			default:
+			}
+
 			// Render gifs and static images while paused
 			s.renderer.BeginSync()
-			keep := map[int]bool{VideoImageID: true}
+			keep := map[int]bool{p.shownVideoID(): true}
 			if err := s.renderOverlays(keep); err != nil {
 				s.renderer.EndSync()
 				return err
@@ -406,26 +551,12 @@ func (s *playSession) videoRenderLoop(p *AVPlayer) error {
 
 			time.Sleep(50 * time.Millisecond)
 			if !p.playing.Load() {
-				pkt.Free()
 				return nil
 			}
 
 			checkSeek()
 		}
 
-		frame, err := s.video.DecodePacket(pkt)
-		pkt.Free()
-
-		if err != nil {
-			return fmt.Errorf("video decode error: %w", err)
-		}
-		if frame == nil {
-			if redraw {
-				p.needsRedrawVid.Store(true)
-			}
-			continue
-		}
-
 		switch seekState {
 		case seekPhaseDiscard:
 			// Phase 1: discard stale frames until we see PTS <= target
@@ -442,39 +573,52 @@ func (s *playSession) videoRenderLoop(p *AVPlayer) error {
 			seekState = seekPhaseNone
 		}
 
-		// Sync to audio clock (skip frame if behind, wait if ahead)
-		if s.audio != nil && s.audio.IsPlaying() {
-			audioTime := s.audio.Time()
-			diff := frame.PTS - audioTime
-
-			if diff > SyncThreshold {
-				time.Sleep(time.Duration(diff * float64(time.Second) * 0.2))
-			} else if diff < -SyncThreshold {
+		// Sync to audio clock (skip frame if behind, wait if ahead).
+		// A stepped frame is drawn as soon as it's decoded.
+		if stepping {
+			stepping = false
+		} else if s.audio != nil && s.audio.IsPlaying() {
+			if !s.syncFrame(frame.PTS - s.audio.Time()) {
 				continue
 			}
 		} else if s.audio == nil {
 			elapsed := time.Since(s.wallFallbackStartTime).Seconds()
-			diff := frame.PTS - s.wallFallbackStartPTS - elapsed
-
-			if diff > SyncThreshold {
-				time.Sleep(time.Duration(diff * float64(time.Second) * 0.2))
-			} else if diff < -SyncThreshold {
+			if !s.syncFrame(frame.PTS - s.wallFallbackStartPTS - elapsed) {
 				continue
 			}
 		}
 
+		// Frames queued before a resize were scaled to the old size; drop them
+		// rather than draw them into the new layout. A paused redraw waits for
+		// the first frame at the new size.
+		s.layoutMu.Lock()
+		row, col := s.videoRow, s.videoCol
+		dstW, dstH := s.video.DstSize()
+		s.layoutMu.Unlock()
+		if frame.Width != dstW || frame.Height != dstH {
+			if p.paused.Load() {
+				p.needsRedrawVid.Store(true)
+			}
+			continue
+		}
+
+		s.scaleBrightness(frame, p.brightness.Load().(float64))
 		s.drawProgressBar(frame)
 		s.drawBorder(frame)
 
 		// Render all layers in one synchronized update to avoid flickering
 		s.renderer.BeginSync()
 
-		keep := map[int]bool{VideoImageID: true}
+		videoID := p.nextVideoID()
+		keep := map[int]bool{videoID: true}
 
-		if err := s.renderer.RenderImage(frame.RGB, 24, frame.Width, frame.Height, VideoImageID, s.videoRow, s.videoCol); err != nil {
+		renderStart := time.Now()
+		if err := s.renderer.RenderImage(frame.RGB, 24, frame.Width, frame.Height, videoID, row, col); err != nil {
 			s.renderer.EndSync()
 			return fmt.Errorf("render error: %w", err)
 		}
+		s.renderTime += 0.1 * (time.Since(renderStart).Seconds() - s.renderTime)
+		p.videoID.Store(int64(videoID))
 
 		if err := s.renderOverlays(keep); err != nil {
 			s.renderer.EndSync()
@@ -483,9 +627,82 @@ func (s *playSession) videoRenderLoop(p *AVPlayer) error {
 
 		s.renderer.Prune(keep)
 		s.renderer.EndSync()
+		s.shownPTS.Store(math.Float64bits(frame.PTS))
+		if !s.started.IsZero() {
+			log.Printf("playback: first frame after %v (prewarmed %v)", time.Since(s.started).Round(time.Millisecond), s.warm)
+			s.started = time.Time{}
+		}
 	}
 
-	return nil
+	// decodeErr is written before decodeLoop closes frameCh
+	return s.decodeErr
+}
+
+// syncFrame holds back or drops a frame that is diff seconds ahead of the
+// clock (negative = late), per the sync mode. It returns false if the frame
+// should be dropped, or if the session was stopped while it waited.
+//
+//	SyncDrop:     wait a fifth of the drift when early, drop when late
+//	SyncSleep:    wait out the whole drift when early; draw late frames
+//	              anyway unless syncMaxLag behind, trading sync for smoothness
+//	SyncAdaptive: like SyncDrop, counting the average render time as part of
+//	              the drift and never dropping within it, for slow terminals
+func (s *playSession) syncFrame(diff float64) bool {
+	threshold := s.syncThreshold
+	if threshold <= 0 {
+		threshold = SyncThreshold
+	}
+	if s.syncMode == SyncAdaptive {
+		diff -= s.renderTime
+		threshold = max(threshold, 2*s.renderTime)
+	}
+	s.drift.Store(int64(diff * float64(time.Second)))
+
+	late := diff < -threshold
+	if s.syncMode == SyncSleep {
+		late = diff < -syncMaxLag
+	}
+	switch {
+	case late:
+		s.dropped.Add(1)
+		return false
+	case diff > threshold && s.syncMode == SyncSleep:
+		return s.wait(time.Duration(diff * float64(time.Second)))
+	case diff > threshold:
+		return s.wait(time.Duration(diff * float64(time.Second) * 0.2))
+	}
+	return true
+}
+
+// wait sleeps for d, returning false early if the session is stopped
+// meanwhile. A frame far ahead of the clock (a long drift under SyncSleep)
+// would otherwise hold up Stop for the whole wait.
+func (s *playSession) wait(d time.Duration) bool {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return true
+	case <-s.stopCh:
+		return false
+	}
+}
+
+// scaleBrightness scales every channel of the frame by brightness via a
+// lookup table, clamping at 255. 1 leaves the frame alone.
+func (s *playSession) scaleBrightness(frame *Frame, brightness float64) {
+	if brightness == 1 {
+		return
+	}
+	if brightness != s.brightLevel {
+		for i := range s.brightLUT {
+			s.brightLUT[i] = byte(min(float64(i)*brightness+0.5, 255))
+		}
+		s.brightLevel = brightness
+	}
+	for i, c := range frame.RGB {
+		frame.RGB[i] = s.brightLUT[c]
+	}
 }
 
 // drawProgressBar overlays a thin, semi-transparent progress bar near the bottom of the frame.
@@ -618,6 +835,40 @@ func (s *playSession) renderOverlays(keep map[int]bool) error {
 	return nil
 }
 
+// Fit modes for SetFitMode: how a video that isn't the box's aspect ratio
+// fills it.
+const (
+	FitContain = "contain" // scale to fit inside, leaving bars
+	FitCover   = "cover"   // scale to fill, cropping the overflow
+	FitStretch = "stretch" // scale to the box, ignoring aspect ratio
+)
+
+// fitFrame sizes a srcW x srcH video for a maxW x maxH box under mode. The
+// video is scaled to scaleW x scaleH and frames are the centered dstW x dstH
+// crop of that, which is only smaller for FitCover.
+func fitFrame(srcW, srcH, maxW, maxH int, mode string) (scaleW, scaleH, dstW, dstH int) {
+	if maxW == 0 || maxH == 0 {
+		return srcW, srcH, srcW, srcH
+	}
+
+	switch mode {
+	case FitStretch:
+		return maxW, maxH, maxW, maxH
+	case FitCover:
+		srcAspect := float64(srcW) / float64(srcH)
+		dstAspect := float64(maxW) / float64(maxH)
+		if srcAspect > dstAspect {
+			scaleW, scaleH = int(float64(maxH)*srcAspect), maxH
+		} else {
+			scaleW, scaleH = maxW, int(float64(maxW)/srcAspect)
+		}
+		return scaleW, scaleH, min(scaleW, maxW), min(scaleH, maxH)
+	}
+
+	dstW, dstH = fitSize(srcW, srcH, maxW, maxH)
+	return dstW, dstH, dstW, dstH
+}
+
 // fitSize computes aspect-correct dimensions to fit in the target area.
 func fitSize(srcW, srcH, maxW, maxH int) (int, int) {
 	if maxW == 0 || maxH == 0 {
@@ -0,0 +1,23 @@
+package player
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSyncSleepReturnsOnStop(t *testing.T) {
+	s := &playSession{
+		stopCh:        make(chan struct{}),
+		syncMode:      SyncSleep,
+		syncThreshold: 0.1,
+	}
+	time.AfterFunc(20*time.Millisecond, s.stop)
+
+	start := time.Now()
+	if s.syncFrame(10) {
+		t.Error("syncFrame = true after stop, want the frame dropped")
+	}
+	if waited := time.Since(start); waited > time.Second {
+		t.Errorf("syncFrame waited %v after stop, want it to return at once", waited)
+	}
+}
@@ -0,0 +1,376 @@
+package player
+
+import (
+	"bytes"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"sync"
+)
+
+// Sixel palette: a 6x6x6 color cube. Terminals usually give sixel 256 color
+// registers, and a fixed cube keeps encoding cheap enough for video without
+// a per-frame palette search.
+const (
+	sixelLevels    = 6
+	sixelColors    = sixelLevels * sixelLevels * sixelLevels
+	sixelBandPx    = 6   // pixel rows per sixel band
+	alphaThreshold = 128 // RGBA pixels below this alpha are left transparent
+)
+
+// sixelBayer is a 4x4 ordered-dither matrix, scaled to -0.5..0.5 of one cube
+// step when used. It hides the banding the 6-level cube leaves in gradients.
+var sixelBayer = [4][4]int{
+	{0, 8, 2, 10},
+	{12, 4, 14, 6},
+	{3, 11, 1, 9},
+	{15, 7, 13, 5},
+}
+
+// sixelPalette is the color register definitions for the cube, written at the
+// start of every image.
+var sixelPalette = func() []byte {
+	var b bytes.Buffer
+	for i := 0; i < sixelColors; i++ {
+		r, g, bl := i/36, (i/6)%6, i%6
+		// sixel RGB components are percentages
+		fmt.Fprintf(&b, "#%d;2;%d;%d;%d", i, r*100/(sixelLevels-1), g*100/(sixelLevels-1), bl*100/(sixelLevels-1))
+	}
+	return b.Bytes()
+}()
+
+// cellRect is the block of terminal cells an image covers (1-indexed).
+type cellRect struct {
+	row, col   int
+	rows, cols int
+}
+
+func (a cellRect) contains(row, col int) bool {
+	return row >= a.row && row < a.row+a.rows && col >= a.col && col < a.col+a.cols
+}
+
+// SixelRenderer renders images as sixel graphics, for terminals (xterm,
+// mlterm, foot, ...) without the Kitty protocol. Sixel images have no IDs: they
+// are pixels painted into cells, so deleting one means erasing its cells.
+type SixelRenderer struct {
+	mu sync.Mutex
+
+	out io.Writer
+
+	// Terminal dimensions in cells and pixels
+	termCols     int
+	termRows     int
+	termWidthPx  int
+	termHeightPx int
+
+	syncUpdate bool
+
+	renderCache map[int]renderCacheEntry
+	placed      map[int]cellRect // where each image ID was last drawn
+
+	// bits and used are scratch space for encoding one band, reused across
+	// frames: bits[c*width+x] holds the sixel bits of color c in column x
+	bits []byte
+	used []bool
+}
+
+// NewSixelRenderer creates a new sixel graphics renderer
+func NewSixelRenderer(out io.Writer) *SixelRenderer {
+	return &SixelRenderer{out: out}
+}
+
+// SetUseShm is a no-op: sixel data always goes inline.
+func (r *SixelRenderer) SetUseShm(useShm bool) {}
+
+// SetSyncUpdate enables or disables the synchronized-update escapes.
+func (r *SixelRenderer) SetSyncUpdate(syncUpdate bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.syncUpdate = syncUpdate
+}
+
+// SetOutput changes the output writer
+func (r *SixelRenderer) SetOutput(w io.Writer) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.out = w
+}
+
+// SetTerminalSize sets the terminal dimensions (cells and pixels)
+func (r *SixelRenderer) SetTerminalSize(cols, rows, widthPx, heightPx int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.termCols = cols
+	r.termRows = rows
+	r.termWidthPx = widthPx
+	r.termHeightPx = heightPx
+}
+
+// cellSize returns the size of a cell in pixels.
+func (r *SixelRenderer) cellSize() (cellW, cellH int) {
+	if r.termCols > 0 && r.termRows > 0 && r.termWidthPx > 0 && r.termHeightPx > 0 {
+		return r.termWidthPx / r.termCols, r.termHeightPx / r.termRows
+	}
+	return fallbackCellW, fallbackCellH
+}
+
+// rectFor returns the cells a width x height image placed at row, col covers.
+func (r *SixelRenderer) rectFor(width, height, row, col int) cellRect {
+	cellW, cellH := r.cellSize()
+	return cellRect{
+		row:  max(row, 1),
+		col:  max(col, 1),
+		rows: (height + cellH - 1) / cellH,
+		cols: (width + cellW - 1) / cellW,
+	}
+}
+
+// RenderImage renders image data at the given cell position. id only tracks
+// the placement so a later Prune can erase it. format: 24 (RGB24) or 32 (RGBA).
+func (r *SixelRenderer) RenderImage(data []byte, format, width, height, id, row, col int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entry := renderCacheEntry{
+		dataChecksum: crc32.ChecksumIEEE(data),
+		dataLen:      len(data),
+		format:       format,
+		width:        width,
+		height:       height,
+		row:          row,
+		col:          col,
+	}
+	if r.renderCache != nil {
+		if prev, ok := r.renderCache[id]; ok && prev == entry {
+			return nil
+		}
+	} else {
+		r.renderCache = make(map[int]renderCacheEntry)
+		r.placed = make(map[int]cellRect)
+	}
+	r.renderCache[id] = entry
+
+	rect := r.rectFor(width, height, row, col)
+
+	var buf bytes.Buffer
+
+	// Save cursor position
+	buf.WriteString("\x1b7")
+
+	// Painting only covers the new image's cells, so erase whatever the
+	// previous image under this ID left outside them. Transparent pixels don't
+	// paint either, so an RGBA image is drawn on a clean rect.
+	if prev, ok := r.placed[id]; ok {
+		if format == 32 {
+			eraseRect(&buf, prev, nil, r.termRows)
+		} else {
+			eraseRect(&buf, prev, []cellRect{rect}, r.termRows)
+		}
+	}
+	r.placed[id] = rect
+
+	fmt.Fprintf(&buf, "\x1b[%d;%dH", rect.row, rect.col)
+	r.encode(&buf, data, format, width, height)
+
+	// Restore cursor position
+	buf.WriteString("\x1b8")
+
+	_, err := r.out.Write(buf.Bytes())
+	return err
+}
+
+// encode writes data as a sixel image. Pixels are mapped onto the color cube
+// with ordered dithering, and each band is written one color at a time with
+// run-length compression.
+func (r *SixelRenderer) encode(buf *bytes.Buffer, data []byte, format, width, height int) {
+	bpp := format / 8
+	if width <= 0 || height <= 0 || len(data) < width*height*bpp {
+		return
+	}
+
+	// P2=1: pixels left at 0 keep what's underneath (transparency)
+	buf.WriteString("\x1bP0;1;0q")
+	fmt.Fprintf(buf, "\"1;1;%d;%d", width, height)
+	buf.Write(sixelPalette)
+
+	if len(r.bits) < sixelColors*width {
+		r.bits = make([]byte, sixelColors*width)
+	}
+	if r.used == nil {
+		r.used = make([]bool, sixelColors)
+	}
+	colors := make([]int, 0, sixelColors)
+
+	for top := 0; top < height; top += sixelBandPx {
+		colors = colors[:0]
+		for dy := 0; dy < sixelBandPx && top+dy < height; dy++ {
+			y := top + dy
+			line := data[y*width*bpp:]
+			for x := 0; x < width; x++ {
+				p := line[x*bpp:]
+				if bpp == 4 && p[3] < alphaThreshold {
+					continue
+				}
+				c := sixelQuantize(p[0], p[1], p[2], x, y)
+				if !r.used[c] {
+					r.used[c] = true
+					colors = append(colors, c)
+				}
+				r.bits[c*width+x] |= 1 << dy
+			}
+		}
+
+		for i, c := range colors {
+			if i > 0 {
+				buf.WriteByte('$') // back to the start of the band
+			}
+			fmt.Fprintf(buf, "#%d", c)
+			sixelWriteRow(buf, r.bits[c*width:(c+1)*width])
+			r.used[c] = false
+			clear(r.bits[c*width : (c+1)*width])
+		}
+		buf.WriteByte('-') // next band
+	}
+
+	buf.WriteString("\x1b\\")
+}
+
+// sixelQuantize maps a pixel to its dithered color cube index.
+func sixelQuantize(red, green, blue byte, x, y int) int {
+	// threshold in 0..255 space: one cube step is 51
+	t := (sixelBayer[y&3][x&3]*51)/16 - 25
+	level := func(v byte) int {
+		l := (int(v) + t + 25) / 51
+		return max(min(l, sixelLevels-1), 0)
+	}
+	return level(red)*36 + level(green)*6 + level(blue)
+}
+
+// sixelWriteRow writes one color's sixels for a band, using !<n> repeats for
+// runs and dropping trailing empty columns.
+func sixelWriteRow(buf *bytes.Buffer, row []byte) {
+	end := len(row)
+	for end > 0 && row[end-1] == 0 {
+		end--
+	}
+	for x := 0; x < end; {
+		b := row[x]
+		n := 1
+		for x+n < end && row[x+n] == b {
+			n++
+		}
+		ch := byte('?' + b)
+		if n > 3 {
+			fmt.Fprintf(buf, "!%d%c", n, ch)
+		} else {
+			for i := 0; i < n; i++ {
+				buf.WriteByte(ch)
+			}
+		}
+		x += n
+	}
+}
+
+// eraseRect erases the cells of rect not covered by any of keep, stopping at
+// the last of termRows rows (0 = unknown). Erasing the cells clears the sixel
+// pixels or half blocks on them.
+func eraseRect(buf *bytes.Buffer, rect cellRect, keep []cellRect, termRows int) {
+	for row := rect.row; row < rect.row+rect.rows; row++ {
+		if termRows > 0 && row > termRows {
+			break
+		}
+		for col := rect.col; col < rect.col+rect.cols; {
+			if covered(keep, row, col) {
+				col++
+				continue
+			}
+			start := col
+			for col < rect.col+rect.cols && !covered(keep, row, col) {
+				col++
+			}
+			fmt.Fprintf(buf, "\x1b[%d;%dH\x1b[%dX", row, start, col-start)
+		}
+	}
+}
+
+func covered(rects []cellRect, row, col int) bool {
+	for _, rc := range rects {
+		if rc.contains(row, col) {
+			return true
+		}
+	}
+	return false
+}
+
+// BeginSync emits the synchronized-update start escape so the terminal buffers
+// subsequent renders until EndSync is called.
+func (r *SixelRenderer) BeginSync() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.syncUpdate {
+		return
+	}
+	r.out.Write([]byte("\x1b[?2026h"))
+}
+
+// EndSync commits all buffered renders to the screen.
+func (r *SixelRenderer) EndSync() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.syncUpdate {
+		return
+	}
+	r.out.Write([]byte("\x1b[?2026l"))
+}
+
+// Prune erases every placed image whose ID is not in keep, leaving the cells
+// kept images cover alone so the frame that replaced it isn't wiped too.
+func (r *SixelRenderer) Prune(keep map[int]bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var kept []cellRect
+	for id, rect := range r.placed {
+		if keep[id] {
+			kept = append(kept, rect)
+		}
+	}
+
+	var buf bytes.Buffer
+	for id, rect := range r.placed {
+		if keep[id] {
+			continue
+		}
+		delete(r.placed, id)
+		delete(r.renderCache, id)
+		eraseRect(&buf, rect, kept, r.termRows)
+	}
+	if buf.Len() == 0 {
+		return
+	}
+	r.out.Write([]byte("\x1b7"))
+	r.out.Write(buf.Bytes())
+	r.out.Write([]byte("\x1b8"))
+}
+
+// DeleteAllImages erases every placed image.
+func (r *SixelRenderer) DeleteAllImages() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var buf bytes.Buffer
+	for _, rect := range r.placed {
+		eraseRect(&buf, rect, nil, r.termRows)
+	}
+	r.renderCache = nil
+	r.placed = nil
+	if buf.Len() == 0 {
+		return
+	}
+	r.out.Write([]byte("\x1b7"))
+	r.out.Write(buf.Bytes())
+	r.out.Write([]byte("\x1b8"))
+}
+
+// CleanupShm is a no-op: the sixel renderer never uses shared memory.
+func (r *SixelRenderer) CleanupShm() {}
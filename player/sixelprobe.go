@@ -0,0 +1,15 @@
+package player
+
+import (
+	"slices"
+
+	"github.com/njyeung/reels/player/term"
+)
+
+// SixelSupported returns true if the terminal lists sixel graphics (attribute
+// 4) in its primary device attributes.
+//
+// IMPORTANT: MUST BE CALLED BEFORE BUBBLETEA STARTS
+func SixelSupported() bool {
+	return slices.Contains(term.DeviceAttributes(), "4")
+}
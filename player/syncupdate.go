@@ -0,0 +1,25 @@
+package player
+
+import (
+	"regexp"
+
+	"github.com/njyeung/reels/player/term"
+)
+
+// decrpmRegex matches the terminal's DECRQM report for mode 2026:
+// \x1b[?2026;<state>$y
+var decrpmRegex = regexp.MustCompile(`\x1b\[\?2026;(\d)\$y`)
+
+// SyncUpdateSupported returns true if the terminal recognizes the
+// synchronized-update mode (\x1b[?2026h/l). Terminals that don't either
+// ignore the DECRQM query or report the mode as unrecognized.
+//
+// IMPORTANT: MUST BE CALLED BEFORE BUBBLETEA STARTS
+func SyncUpdateSupported() bool {
+	// Query the mode: a supporting terminal answers \x1b[?2026;<state>$y
+	resp := term.Query("\x1b[?2026$p")
+
+	// 1 = set, 2 = reset, 3 = permanently set; 0 = unrecognized, 4 = permanently reset
+	m := decrpmRegex.FindSubmatch(resp)
+	return m != nil && (m[1][0] == '1' || m[1][0] == '2' || m[1][0] == '3')
+}
@@ -1,6 +1,6 @@
 //go:build darwin
 
-package shm
+package term
 
 import "golang.org/x/sys/unix"
 
@@ -0,0 +1,97 @@
+package term
+
+import (
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+
+	"golang.org/x/sys/unix"
+)
+
+// da1Regex matches the terminal's primary device attributes report:
+// \x1b[?<attr>;<attr>;...c
+var da1Regex = regexp.MustCompile(`\x1b\[\?([\d;]*)c`)
+
+// da1 holds the attributes from the last DA1 report Query read, nil until
+// one has been read
+var (
+	da1Mu sync.Mutex
+	da1   []string
+)
+
+// Query writes seq to the terminal and returns its answer. A primary device
+// attributes request (DA1) goes out after seq; every VT100-compatible
+// terminal answers it, and in order, so reading stops at the DA1 report
+// instead of waiting out the 200ms timeout when the terminal ignores seq.
+// Returns nil if stdin isn't a terminal.
+//
+// IMPORTANT: MUST BE CALLED BEFORE BUBBLETEA STARTS
+func Query(seq string) []byte {
+	stdinFd := int(os.Stdin.Fd())
+
+	oldTermios, err := unix.IoctlGetTermios(stdinFd, ioctlGetTermios)
+	if err != nil {
+		return nil
+	}
+
+	// Drain any pending input without waiting for more
+	raw := *oldTermios
+	raw.Lflag &^= unix.ECHO | unix.ICANON | unix.ISIG
+	raw.Iflag &^= unix.IXON | unix.ICRNL
+	raw.Cc[unix.VMIN] = 0
+	raw.Cc[unix.VTIME] = 0
+	if err := unix.IoctlSetTermios(stdinFd, ioctlSetTermios, &raw); err != nil {
+		return nil
+	}
+	defer unix.IoctlSetTermios(stdinFd, ioctlSetTermios, oldTermios)
+	drain := make([]byte, 256)
+	for {
+		if n, _ := os.Stdin.Read(drain); n == 0 {
+			break
+		}
+	}
+
+	raw.Cc[unix.VTIME] = 2 // 200ms timeout
+	if err := unix.IoctlSetTermios(stdinFd, ioctlSetTermios, &raw); err != nil {
+		return nil
+	}
+
+	os.Stdout.WriteString(seq + "\x1b[c")
+
+	var resp []byte
+	buf := make([]byte, 256)
+	for len(resp) < 4096 {
+		n, _ := os.Stdin.Read(buf)
+		if n == 0 {
+			break
+		}
+		resp = append(resp, buf[:n]...)
+		if m := da1Regex.FindSubmatch(resp); m != nil {
+			da1Mu.Lock()
+			da1 = strings.Split(string(m[1]), ";")
+			da1Mu.Unlock()
+			break
+		}
+	}
+	return resp
+}
+
+// DeviceAttributes returns the attributes of the terminal's DA1 report (4 is
+// sixel graphics), reusing the one an earlier Query read if there was one.
+//
+// IMPORTANT: MUST BE CALLED BEFORE BUBBLETEA STARTS
+func DeviceAttributes() []string {
+	da1Mu.Lock()
+	attrs := da1
+	da1Mu.Unlock()
+	if attrs != nil {
+		return attrs
+	}
+
+	Query("")
+
+	da1Mu.Lock()
+	defer da1Mu.Unlock()
+	return da1
+}
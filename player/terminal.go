@@ -12,6 +12,31 @@ var (
 	VideoHeightChars = 1
 )
 
+// Cell sizes in pixels outside this range mean the terminal isn't reporting
+// its pixel size (0x0 is common) or is reporting it wrong. Cells are roughly
+// twice as tall as they are wide, so the height bound is doubled.
+const (
+	minCellPx  = 4
+	maxCellWPx = 40
+	maxCellHPx = 80
+)
+
+// fallbackCellW and fallbackCellH are assumed when the reported cell size is
+// implausible (see SetFallbackCellSize)
+var (
+	fallbackCellW = 10
+	fallbackCellH = 20
+)
+
+// SetFallbackCellSize sets the cell size in pixels GetTerminalSize assumes
+// when the terminal reports an implausible one. Ignored unless both are > 0.
+func SetFallbackCellSize(width, height int) {
+	if width > 0 && height > 0 {
+		fallbackCellW = width
+		fallbackCellH = height
+	}
+}
+
 // ComputeVideoDimensions calculates the video character dimensions from pixel dimensions.
 // Call this after loading settings and on terminal resize to update VideoWidthChars and VideoHeightChars.
 func ComputeVideoCharacterDimensions(videoWidthPx, videoHeightPx int) {
@@ -60,5 +85,26 @@ func GetTerminalSize() (cols, rows, widthPx, heightPx int, err error) {
 	if err != nil {
 		return 0, 0, 0, 0, err
 	}
-	return int(ws.Col), int(ws.Row), int(ws.Xpixel), int(ws.Ypixel), nil
+	cols, rows = int(ws.Col), int(ws.Row)
+	widthPx, heightPx = int(ws.Xpixel), int(ws.Ypixel)
+	if cols > 0 && rows > 0 && !plausibleCell(widthPx/cols, heightPx/rows) {
+		widthPx = cols * fallbackCellW
+		heightPx = rows * fallbackCellH
+	}
+	return cols, rows, widthPx, heightPx, nil
+}
+
+// ReportedCellSize returns the cell size in pixels as the terminal reports it,
+// before any fallback, and whether it looks believable.
+func ReportedCellSize() (cellW, cellH int, ok bool) {
+	ws, err := unix.IoctlGetWinsize(int(os.Stdout.Fd()), unix.TIOCGWINSZ)
+	if err != nil || ws.Col == 0 || ws.Row == 0 {
+		return 0, 0, false
+	}
+	cellW, cellH = int(ws.Xpixel)/int(ws.Col), int(ws.Ypixel)/int(ws.Row)
+	return cellW, cellH, plausibleCell(cellW, cellH)
+}
+
+func plausibleCell(cellW, cellH int) bool {
+	return cellW >= minCellPx && cellW <= maxCellWPx && cellH >= minCellPx && cellH <= maxCellHPx
 }
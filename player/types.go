@@ -50,16 +50,37 @@ type Clock interface {
 	IsPlaying() bool
 }
 
-// Renderer handles terminal graphics output
+// Renderer handles terminal graphics output. Implementations are registered
+// in renderers.go and constructed by name with NewRenderer.
 type Renderer interface {
-	// RenderImage renders image data at a cell position with a Kitty image ID
+	// RenderImage renders image data at a cell position with the given image ID.
+	// format: 24 (RGB24) or 32 (RGBA)
 	RenderImage(data []byte, format, width, height, id, row, col int) error
 
-	// DeleteImage removes a specific Kitty image by ID
-	DeleteImage(id int) error
+	// Prune deletes every displayed image whose ID is not in keep
+	Prune(keep map[int]bool)
 
-	// ClearTerminal deletes all kitty images from the terminal
-	ClearTerminal() error
+	// BeginSync and EndSync bracket a frame's renders so they appear at once
+	BeginSync()
+	EndSync()
+
+	// SetOutput changes the output writer
+	SetOutput(w io.Writer)
+
+	// SetTerminalSize sets the terminal dimensions (cells and pixels)
+	SetTerminalSize(cols, rows, widthPx, heightPx int)
+
+	// SetUseShm and SetSyncUpdate toggle optional transports/escapes;
+	// renderers that don't support them ignore the call
+	SetUseShm(useShm bool)
+	SetSyncUpdate(syncUpdate bool)
+
+	// DeleteAllImages removes every image this renderer has placed, so none
+	// linger on the normal screen after the alt screen is left
+	DeleteAllImages()
+
+	// CleanupShm releases any shared memory left behind on shutdown
+	CleanupShm()
 }
 
 // Frame represents a decoded video frame
@@ -80,15 +101,22 @@ type AudioSamples struct {
 }
 
 const (
-	// SyncThreshold is the max drift before we skip/wait frames in video
+	// SyncThreshold is the default max drift before we skip/wait frames in
+	// video, see SetSync
 	SyncThreshold = 0.1 // 100ms
 
+	// syncMaxLag is how far behind SyncSleep lets a frame get before it
+	// drops it after all
+	syncMaxLag = 1.0
+
 	// AudioSampleRate for resampling
 	AudioSampleRate = 44100
 
 	// Kitty image IDs
 	VideoImageID  = 1
+	VideoBackID   = 2 // alternate video ID for hold_frame
 	PfpImageID    = 101
 	GifImageID    = 200
 	StaticImageID = 300
+	ThumbImageID  = 400
 )
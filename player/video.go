@@ -19,12 +19,26 @@ type VideoDecoder struct {
 	dstWidth  int
 	dstHeight int
 
+	// scaleWidth x scaleHeight is what swsCtx scales to; frames are the
+	// centered dstWidth x dstHeight crop of it (see SetCroppedSize)
+	scaleWidth  int
+	scaleHeight int
+
 	timeBase astiav.Rational
 
+	// swsPixFmt is the source pixel format swsCtx was created for
+	swsPixFmt astiav.PixelFormat
+
 	mu     sync.Mutex
 	closed bool
 }
 
+// DecoderAvailable reports whether the linked FFmpeg has a decoder called
+// name (e.g. "h264", "aac").
+func DecoderAvailable(name string) bool {
+	return astiav.FindDecoderByName(name) != nil
+}
+
 // NewVideoDecoder creates a video decoder from codec parameters
 func NewVideoDecoder(codecParams *astiav.CodecParameters, timeBase astiav.Rational) (*VideoDecoder, error) {
 	v := &VideoDecoder{
@@ -33,6 +47,9 @@ func NewVideoDecoder(codecParams *astiav.CodecParameters, timeBase astiav.Ration
 		srcHeight: codecParams.Height(),
 		dstWidth:  codecParams.Width(),
 		dstHeight: codecParams.Height(),
+
+		scaleWidth:  codecParams.Width(),
+		scaleHeight: codecParams.Height(),
 	}
 
 	// Find decoder
@@ -69,15 +86,24 @@ func NewVideoDecoder(codecParams *astiav.CodecParameters, timeBase astiav.Ration
 
 // SetSize sets the output dimensions for scaling
 func (v *VideoDecoder) SetSize(width, height int) error {
+	return v.SetCroppedSize(width, height, width, height)
+}
+
+// SetCroppedSize scales frames to scaleW x scaleH and outputs the centered
+// dstW x dstH part of them, for fit modes that crop. dstW and dstH must not
+// be larger than the scaled size.
+func (v *VideoDecoder) SetCroppedSize(scaleW, scaleH, dstW, dstH int) error {
 	v.mu.Lock()
 	defer v.mu.Unlock()
 
-	if width == v.dstWidth && height == v.dstHeight {
+	if scaleW == v.scaleWidth && scaleH == v.scaleHeight && dstW == v.dstWidth && dstH == v.dstHeight {
 		return nil
 	}
 
-	v.dstWidth = width
-	v.dstHeight = height
+	v.scaleWidth = scaleW
+	v.scaleHeight = scaleH
+	v.dstWidth = min(dstW, scaleW)
+	v.dstHeight = min(dstH, scaleH)
 
 	// Recreate sws context with new dimensions
 	if v.swsCtx != nil {
@@ -89,7 +115,7 @@ func (v *VideoDecoder) SetSize(width, height int) error {
 }
 
 func (v *VideoDecoder) initSwsContext(srcPixFmt astiav.PixelFormat) error {
-	if v.dstWidth == 0 || v.dstHeight == 0 {
+	if v.scaleWidth == 0 || v.scaleHeight == 0 {
 		return nil
 	}
 
@@ -97,7 +123,7 @@ func (v *VideoDecoder) initSwsContext(srcPixFmt astiav.PixelFormat) error {
 	var err error
 	v.swsCtx, err = astiav.CreateSoftwareScaleContext(
 		v.srcWidth, v.srcHeight, srcPixFmt,
-		v.dstWidth, v.dstHeight, astiav.PixelFormatRgb24,
+		v.scaleWidth, v.scaleHeight, astiav.PixelFormatRgb24,
 		astiav.NewSoftwareScaleContextFlags(astiav.SoftwareScaleContextFlagBilinear),
 	)
 	if err != nil {
@@ -107,8 +133,8 @@ func (v *VideoDecoder) initSwsContext(srcPixFmt astiav.PixelFormat) error {
 	// Unref old frame data so AllocBuffer recomputes linesize for new dimensions
 	v.rgbFrame.Unref()
 
-	v.rgbFrame.SetWidth(v.dstWidth)
-	v.rgbFrame.SetHeight(v.dstHeight)
+	v.rgbFrame.SetWidth(v.scaleWidth)
+	v.rgbFrame.SetHeight(v.scaleHeight)
 	v.rgbFrame.SetPixelFormat(astiav.PixelFormatRgb24)
 
 	if err := v.rgbFrame.AllocBuffer(1); err != nil {
@@ -118,6 +144,23 @@ func (v *VideoDecoder) initSwsContext(srcPixFmt astiav.PixelFormat) error {
 	return nil
 }
 
+// prime creates the scaling context ahead of the first frame. srcPixFmt comes
+// from the stream's codec parameters; if the decoder outputs a different
+// format the context is rebuilt on first decode.
+func (v *VideoDecoder) prime(srcPixFmt astiav.PixelFormat) error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if v.closed || v.swsCtx != nil {
+		return nil
+	}
+	if err := v.initSwsContext(srcPixFmt); err != nil {
+		return err
+	}
+	v.swsPixFmt = srcPixFmt
+	return nil
+}
+
 // DecodePacket decodes a video packet and returns an RGB frame
 func (v *VideoDecoder) DecodePacket(pkt *astiav.Packet) (*Frame, error) {
 	v.mu.Lock()
@@ -146,12 +189,18 @@ func (v *VideoDecoder) DecodePacket(pkt *astiav.Packet) (*Frame, error) {
 	// Calculate duration from packet duration
 	var duration = float64(pkt.Duration()) * float64(v.timeBase.Num()) / float64(v.timeBase.Den())
 
-	// Initialize sws context if needed
+	// Initialize sws context if needed (or if a primed one guessed the
+	// wrong source format)
+	if v.swsCtx != nil && v.swsPixFmt != v.frame.PixelFormat() {
+		v.swsCtx.Free()
+		v.swsCtx = nil
+	}
 	if v.swsCtx == nil {
 		if err := v.initSwsContext(v.frame.PixelFormat()); err != nil {
 			v.frame.Unref()
 			return nil, err
 		}
+		v.swsPixFmt = v.frame.PixelFormat()
 	}
 
 	if err := v.swsCtx.ScaleFrame(v.frame, v.rgbFrame); err != nil {
@@ -167,8 +216,13 @@ func (v *VideoDecoder) DecodePacket(pkt *astiav.Packet) (*Frame, error) {
 	}
 
 	// Copy the data since the frame buffer will be reused
-	rgb := make([]byte, len(rgbBytes))
-	copy(rgb, rgbBytes)
+	var rgb []byte
+	if v.dstWidth == v.scaleWidth && v.dstHeight == v.scaleHeight {
+		rgb = make([]byte, len(rgbBytes))
+		copy(rgb, rgbBytes)
+	} else {
+		rgb = cropRGB(rgbBytes, v.scaleWidth, v.scaleHeight, v.dstWidth, v.dstHeight)
+	}
 
 	v.frame.Unref()
 
@@ -181,6 +235,24 @@ func (v *VideoDecoder) DecodePacket(pkt *astiav.Packet) (*Frame, error) {
 	}, nil
 }
 
+// cropRGB copies the centered dstW x dstH part of a srcW x srcH RGB24 image.
+func cropRGB(src []byte, srcW, srcH, dstW, dstH int) []byte {
+	x0, y0 := (srcW-dstW)/2, (srcH-dstH)/2
+	rgb := make([]byte, dstW*dstH*3)
+	for y := 0; y < dstH; y++ {
+		off := ((y0+y)*srcW + x0) * 3
+		copy(rgb[y*dstW*3:(y+1)*dstW*3], src[off:off+dstW*3])
+	}
+	return rgb
+}
+
+// DstSize returns the dimensions frames are currently output at
+func (v *VideoDecoder) DstSize() (int, int) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.dstWidth, v.dstHeight
+}
+
 // SourceSize returns the original video dimensions
 func (v *VideoDecoder) SourceSize() (int, int) {
 	return v.srcWidth, v.srcHeight
@@ -34,6 +34,23 @@ class KittyDriver:
             text=True,
         )
     
+    def resize(self, cols: int, rows: int):
+        subprocess.run(
+            [
+                "kitten", "@",
+                "--to", f"unix:{KITTY_SOCKET_PATH}",
+                "resize-os-window",
+                "--unit", "cells",
+                "--width", str(cols),
+                "--height", str(rows),
+            ],
+            capture_output=True,
+            text=True,
+        )
+
+    def is_running(self) -> bool:
+        return self.proc is not None and self.proc.poll() is None
+
     def __enter__(self):
         # build the binary
         binary = os.path.join(PROJECT_ROOT, "reels")
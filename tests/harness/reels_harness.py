@@ -24,6 +24,12 @@ class ReelsTestHarness:
     def press(self, key: str):
         self.kitty.send_key(key)
 
+    def resize(self, cols: int, rows: int):
+        self.kitty.resize(cols, rows)
+
+    def is_running(self) -> bool:
+        return self.kitty.is_running()
+
     def get_screen(self) -> str:
         return self.kitty.get_text()
 
@@ -1,10 +1,32 @@
 import random
+import time
 from harness.reels_harness import ReelsTestHarness
 from utils import sleep, extract_reel_code
 with ReelsTestHarness() as h:
     url = h.get_url()
     print(f"initial url: {url}")
 
+    # Drag-resize the window. Frames queued at the old size are dropped, and
+    # a dropped frame must not leave the terminal in a synchronized update.
+    sizes = [(120, 40), (90, 30), (160, 50), (100, 35), (140, 45)]
+    for i in range(50):
+        cols, rows = sizes[i % len(sizes)]
+        h.resize(cols, rows)
+        time.sleep(random.uniform(0.02, 0.15))
+    sleep(2)
+
+    assert h.is_running(), "reels exited while resizing"
+    h.assert_on_screen("@", 5)
+    screen = h.get_screen()
+    h.press("j")
+    h.assert_url_changed(url, 10)
+    deadline = time.time() + 5
+    while h.get_screen() == screen:
+        assert time.time() < deadline, "screen stopped updating after resizing"
+        time.sleep(0.5)
+    url = h.get_url()
+    print(f"PASS: playback continued after resizing, now at {url}")
+
     for i in range(500):
         h.press("j")
         sleep(7)
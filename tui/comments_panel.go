@@ -1,7 +1,9 @@
 package tui
 
 import (
+	"cmp"
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/njyeung/reels/backend"
@@ -17,6 +19,11 @@ type CommentsPanel struct {
 	scroll   int  // first visible comment index
 	loading  bool // true while fetching more comments
 
+	// loaded is set once comments for reelPK arrive (possibly none); failed
+	// once the capture timeout passes without them
+	loaded bool
+	failed bool
+
 	// Which reel these comments belong to
 	reelPK string
 
@@ -27,6 +34,32 @@ type CommentsPanel struct {
 	// GIF state
 	gifAnims      map[string]*player.GifAnimation
 	gifCellHeight int
+
+	// indent and separator are comment_indent and comment_separator
+	indent    int
+	separator string
+
+	// verifiedGlyph is glyph_verified, drawn after verified usernames
+	verifiedGlyph string
+
+	// sort is the order threads are shown in (key_comment_sort)
+	sort commentSort
+}
+
+// commentSort orders the panel's threads. Replies stay under their parent in
+// the order they arrived.
+type commentSort int
+
+const (
+	commentSortTop    commentSort = iota // most liked first
+	commentSortNewest                    // most recent first
+)
+
+func (s commentSort) label() string {
+	if s == commentSortNewest {
+		return "Newest"
+	}
+	return "Top"
 }
 
 // NewCommentsPanel creates a new CommentsPanel instance
@@ -34,6 +67,9 @@ func NewCommentsPanel() *CommentsPanel {
 	return &CommentsPanel{
 		comments:      make([]backend.Comment, 0),
 		gifCellHeight: backend.GetSettings().GifCellHeight,
+		indent:        backend.GetSettings().CommentIndent,
+		separator:     backend.GetSettings().CommentSeparator,
+		verifiedGlyph: backend.GetSettings().GlyphVerified,
 	}
 }
 
@@ -53,7 +89,9 @@ func (cp *CommentsPanel) Open(reelPK string) {
 	if cp.reelPK != reelPK {
 		cp.comments = make([]backend.Comment, 0)
 		cp.gifAnims = nil
+		cp.loaded = false
 	}
+	cp.failed = false
 
 	cp.reelPK = reelPK
 }
@@ -76,21 +114,40 @@ func (cp *CommentsPanel) Clear() {
 	cp.scroll = 0
 	cp.reelPK = ""
 	cp.gifAnims = nil
+	cp.loaded = false
+	cp.failed = false
+}
+
+// Loaded reports whether comments for the open reel have arrived.
+func (cp *CommentsPanel) Loaded() bool {
+	return cp.loaded
+}
+
+// Failed reports whether the comment capture timed out.
+func (cp *CommentsPanel) Failed() bool {
+	return cp.failed
+}
+
+// SetFailed marks the comment capture as timed out (or clears it on retry).
+func (cp *CommentsPanel) SetFailed(failed bool) {
+	cp.failed = failed
 }
 
 // loadGifs loads GIF animations from disk for comments that have a GifPath
 func (cp *CommentsPanel) loadGifs() {
-	if cp.gifAnims == nil {
-		cp.gifAnims = make(map[string]*player.GifAnimation)
-	}
-
 	_, rows, _, termH, err := player.GetTerminalSize()
 	if err != nil || rows == 0 || termH == 0 {
 		return
 	}
-	cellH := termH / rows
-	gifHeightPx := cp.gifCellHeight * cellH
+	cp.decodeGifs(cp.gifCellHeight * (termH / rows))
+}
 
+// decodeGifs decodes the GIFs not yet loaded at heightPx pixels tall. A GIF
+// that fails to decode is left out of gifAnims, see commentText.
+func (cp *CommentsPanel) decodeGifs(heightPx int) {
+	if cp.gifAnims == nil {
+		cp.gifAnims = make(map[string]*player.GifAnimation)
+	}
 	for _, c := range cp.comments {
 		if c.GifPath == "" {
 			continue
@@ -98,7 +155,7 @@ func (cp *CommentsPanel) loadGifs() {
 		if _, ok := cp.gifAnims[c.PK]; ok {
 			continue
 		}
-		anim, err := player.LoadGif(c.GifPath, gifHeightPx)
+		anim, err := player.LoadGif(c.GifPath, heightPx)
 		if err != nil {
 			continue
 		}
@@ -115,8 +172,26 @@ func (cp *CommentsPanel) ResizeGifs() {
 	cp.loadGifs()
 }
 
+// commentText returns the text shown for a comment. A GIF comment whose GIF
+// failed to load (corrupt download, unsupported format) is shown as a "[GIF]"
+// text line so it doesn't leave a blank slot.
+func (cp *CommentsPanel) commentText(comment backend.Comment) string {
+	text := strings.ReplaceAll(comment.Text, "\n", " ")
+	if comment.GifPath == "" {
+		return text
+	}
+	if _, ok := cp.gifAnims[comment.PK]; ok {
+		return text
+	}
+	if text == "" {
+		return "[GIF]"
+	}
+	return "[GIF] " + text
+}
+
 // commentLines returns how many terminal lines comment i occupies: one line for
-// the username plus either the reserved GIF rows or the wrapped text lines.
+// the username, either the reserved GIF rows or the wrapped text lines, then
+// the like line.
 func (cp *CommentsPanel) commentLines(i int) int {
 	comment := cp.comments[i]
 	lines := 1 // username
@@ -124,11 +199,15 @@ func (cp *CommentsPanel) commentLines(i int) int {
 		lines += cp.gifCellHeight
 	} else {
 		_, _, wrapWidth := cp.replyIndent(comment.ParentCommentID != "")
-		lines += len(wrapByWidth(strings.ReplaceAll(comment.Text, "\n", " "), wrapWidth))
+		lines += len(wrapByWidth(cp.commentText(comment), wrapWidth))
 	}
+	lines++ // like line
 	if cp.showsReplyHint(i) {
 		lines++ // "↳ N replies" hint
 	}
+	if cp.showsSeparator(i) {
+		lines++
+	}
 	return lines
 }
 
@@ -173,22 +252,15 @@ func (cp *CommentsPanel) SetComments(reelPK string, comments []backend.Comment)
 		return false
 	}
 
-	var cursorPK, scrollPK string
-	if len(cp.comments) > 0 {
-		cursorPK = cp.comments[cp.cursor].PK
-		scrollPK = cp.comments[cp.scroll].PK
-	}
-
-	cp.comments = comments
+	prev := cp.comments
+	cp.comments = sortComments(comments, cp.sort)
+	cp.loaded = true
+	cp.failed = false
 	cp.loadGifs()
 
-	// Follow each anchor to its new position.
-	if i, ok := indexOfPK(comments, cursorPK); ok {
-		cp.cursor = i
-	}
-	if i, ok := indexOfPK(comments, scrollPK); ok {
-		cp.scroll = i
-	}
+	// Background updates (pagination, replies) keep the reader in place
+	cp.cursor = reanchor(prev, cp.comments, cp.cursor)
+	cp.scroll = reanchor(prev, cp.comments, cp.scroll)
 
 	cp.clampCursor()
 	cp.clampScroll()
@@ -196,6 +268,60 @@ func (cp *CommentsPanel) SetComments(reelPK string, comments []backend.Comment)
 	return true
 }
 
+// ToggleSort switches between top and newest first, re-sorting the comments
+// already loaded and going back to the top of the list.
+func (cp *CommentsPanel) ToggleSort() {
+	if cp.sort == commentSortTop {
+		cp.sort = commentSortNewest
+	} else {
+		cp.sort = commentSortTop
+	}
+	cp.comments = sortComments(cp.comments, cp.sort)
+	cp.cursor = 0
+	cp.scroll = 0
+}
+
+// sortComments returns comments with their threads in s order. Each thread is
+// a top-level comment and the replies spliced in after it, and moves as one.
+// Ties keep the order comments arrived in.
+func sortComments(comments []backend.Comment, s commentSort) []backend.Comment {
+	var threads [][]backend.Comment
+	for i, c := range comments {
+		if c.ParentCommentID == "" || len(threads) == 0 {
+			threads = append(threads, comments[i:i+1])
+			continue
+		}
+		last := len(threads) - 1
+		threads[last] = comments[i-len(threads[last]) : i+1]
+	}
+
+	slices.SortStableFunc(threads, func(a, b []backend.Comment) int {
+		if s == commentSortNewest {
+			return cmp.Compare(b[0].CreatedAt, a[0].CreatedAt)
+		}
+		return cmp.Compare(b[0].CommentLikeCount, a[0].CommentLikeCount)
+	})
+
+	sorted := make([]backend.Comment, 0, len(comments))
+	for _, t := range threads {
+		sorted = append(sorted, t...)
+	}
+	return sorted
+}
+
+// reanchor returns where the comment at index old of prev sits in comments. If
+// it's gone (e.g. a reply whose thread was collapsed) it anchors to the
+// nearest earlier comment still present instead, so the view doesn't jump.
+// Falls back to old when nothing before it survived.
+func reanchor(prev, comments []backend.Comment, old int) int {
+	for i := min(old, len(prev)-1); i >= 0; i-- {
+		if j, ok := indexOfPK(comments, prev[i].PK); ok {
+			return j
+		}
+	}
+	return old
+}
+
 // indexOfPK returns the index of the comment with the given PK and whether it
 // was found.
 func indexOfPK(comments []backend.Comment, pk string) (int, bool) {
@@ -245,6 +371,23 @@ func (cp *CommentsPanel) CursorComment() (backend.Comment, bool) {
 	return cp.comments[cp.cursor], true
 }
 
+// SetLiked sets whether the viewer likes the comment with pk, adjusting its
+// like count to match.
+func (cp *CommentsPanel) SetLiked(pk string, liked bool) {
+	for i := range cp.comments {
+		c := &cp.comments[i]
+		if c.PK != pk || c.HasLikedComment == liked {
+			continue
+		}
+		c.HasLikedComment = liked
+		if liked {
+			c.CommentLikeCount++
+		} else {
+			c.CommentLikeCount = max(c.CommentLikeCount-1, 0)
+		}
+	}
+}
+
 // RepliesLoaded reports whether the given parent comment's replies are currently
 // spliced into the list.
 func (cp *CommentsPanel) RepliesLoaded(parentPK string) bool {
@@ -270,14 +413,43 @@ func (cp *CommentsPanel) showsReplyHint(i int) bool {
 	return true
 }
 
+// showsSeparator reports whether a comment_separator line follows comment i:
+// after the last comment of each thread, i.e. before the next top-level one.
+func (cp *CommentsPanel) showsSeparator(i int) bool {
+	if cp.separator == "" || cp.separator == "none" {
+		return false
+	}
+	return i+1 < len(cp.comments) && cp.comments[i+1].ParentCommentID == ""
+}
+
+// separatorLine renders the comment_separator line for the given width.
+func (cp *CommentsPanel) separatorLine(width int) string {
+	if cp.separator == "line" {
+		return gray700.Render(strings.Repeat("─", max(width, 0)))
+	}
+	return ""
+}
+
 // replyIndent returns the leading padding for a comment's username line, its
 // text lines, and the wrap width, distinguishing replies (extra indent) from
-// top-level comments.
+// top-level comments. Text sits comment_indent columns in from its username.
 func (cp *CommentsPanel) replyIndent(isReply bool) (userIndent, textIndent string, wrapWidth int) {
 	if isReply {
-		return "  ", "    ", cp.width - 4
+		return strings.Repeat(" ", cp.indent), strings.Repeat(" ", 2*cp.indent), cp.width - 2*cp.indent
+	}
+	return "", strings.Repeat(" ", cp.indent), cp.width - cp.indent
+}
+
+// likeLine renders the heart and like count shown under a comment: filled
+// if the viewer liked it.
+func likeLine(comment backend.Comment) string {
+	if comment.HasLikedComment {
+		return pink400.Render("♥ " + formatLikeCount(comment.CommentLikeCount))
+	}
+	if comment.CommentLikeCount == 0 {
+		return gray600.Render("♡")
 	}
-	return "", "  ", cp.width - 2
+	return gray400.Render("♡ " + formatLikeCount(comment.CommentLikeCount))
 }
 
 // replyHintText renders the "↳ N replies" hint label for a parent comment.
@@ -295,9 +467,17 @@ func replyHintText(n int) string {
 //
 // Renders TUI text for the comments section. Reserves space for gifs, which are handled separately
 func (cp *CommentsPanel) View(width, height int, padding string) string {
-	if !cp.isOpen || len(cp.comments) == 0 {
+	if !cp.isOpen {
 		return ""
 	}
+	if len(cp.comments) == 0 {
+		if !cp.failed {
+			return ""
+		}
+		retry := displayKeys(backend.GetSettings().KeysCommentsOpen)
+		return padding + purple400.Bold(true).Underline(true).Render("Comments") + "\n" +
+			padding + gray400.Render(truncateByWidth("Couldn't load comments. "+retry+" to retry", width)) + "\n"
+	}
 
 	cp.width = width
 	cp.height = height
@@ -305,7 +485,8 @@ func (cp *CommentsPanel) View(width, height int, padding string) string {
 	var b strings.Builder
 
 	// Header
-	header := purple400.Bold(true).Underline(true).Render("Comments")
+	header := purple400.Bold(true).Underline(true).Render(fmt.Sprintf("Comments (%d)", len(cp.comments))) +
+		gray400.Render(" · "+cp.sort.label())
 	b.WriteString(padding + header + "\n")
 	availableLines := height - 2
 	if availableLines < 1 {
@@ -325,7 +506,10 @@ func (cp *CommentsPanel) View(width, height int, padding string) string {
 		}
 		userPart := usernameStyle.Render("@" + comment.Username)
 		if comment.IsVerified {
-			userPart += " " + blue500.Render("✓")
+			userPart += " " + blue500.Render(cp.verifiedGlyph)
+		}
+		if ago := formatRelativeTime(comment.CreatedAt); ago != "" {
+			userPart += " " + gray600.Render(ago)
 		}
 
 		// For GIF comments, require room for username + full cp.gifCellHeight
@@ -347,7 +531,7 @@ func (cp *CommentsPanel) View(width, height int, padding string) string {
 			linesUsed += cp.gifCellHeight
 		} else {
 			// Write comment text lines
-			commentLines := wrapByWidth(strings.ReplaceAll(comment.Text, "\n", " "), wrapWidth)
+			commentLines := wrapByWidth(cp.commentText(comment), wrapWidth)
 			for _, line := range commentLines {
 				if linesUsed >= availableLines {
 					break
@@ -357,9 +541,19 @@ func (cp *CommentsPanel) View(width, height int, padding string) string {
 			}
 		}
 
+		if linesUsed < availableLines {
+			b.WriteString(padding + textIndent + likeLine(comment) + "\n")
+			linesUsed++
+		}
+
 		// Reply hint under a top-level comment whose replies aren't loaded yet
 		if cp.showsReplyHint(i) && linesUsed < availableLines {
-			b.WriteString(padding + "    " + gray400.Render(replyHintText(comment.ChildCommentCount)) + "\n")
+			b.WriteString(padding + strings.Repeat(" ", 2*cp.indent) + gray400.Render(replyHintText(comment.ChildCommentCount)) + "\n")
+			linesUsed++
+		}
+
+		if cp.showsSeparator(i) && linesUsed < availableLines {
+			b.WriteString(padding + cp.separatorLine(width) + "\n")
 			linesUsed++
 		}
 	}
@@ -388,11 +582,11 @@ func (cp *CommentsPanel) VisibleGifSlots(width, height, baseRow, baseCol int) []
 		comment := cp.comments[i]
 
 		// Replies are indented, matching View's layout.
-		wrapWidth := width - 2
-		gifCol := baseCol + 2
+		wrapWidth := width - cp.indent
+		gifCol := baseCol + cp.indent
 		if comment.ParentCommentID != "" {
-			wrapWidth = wrapWidth - 2
-			gifCol = gifCol + 2
+			wrapWidth = wrapWidth - cp.indent
+			gifCol = gifCol + cp.indent
 		}
 
 		// For GIF comments, require room for username + full cp.gifCellHeight
@@ -419,7 +613,7 @@ func (cp *CommentsPanel) VisibleGifSlots(width, height, baseRow, baseCol int) []
 			currentRow += cp.gifCellHeight
 		} else {
 			// Advance past text lines
-			commentLines := wrapByWidth(strings.ReplaceAll(comment.Text, "\n", " "), wrapWidth)
+			commentLines := wrapByWidth(cp.commentText(comment), wrapWidth)
 			for range commentLines {
 				if linesUsed >= availableLines {
 					break
@@ -429,16 +623,46 @@ func (cp *CommentsPanel) VisibleGifSlots(width, height, baseRow, baseCol int) []
 			}
 		}
 
-		// Reply hint occupies one line, matching View.
+		// Like line, reply hint and separator occupy one line each, matching View.
+		if linesUsed < availableLines {
+			linesUsed++
+			currentRow++
+		}
 		if cp.showsReplyHint(i) && linesUsed < availableLines {
 			linesUsed++
 			currentRow++
 		}
+		if cp.showsSeparator(i) && linesUsed < availableLines {
+			linesUsed++
+			currentRow++
+		}
 	}
 
 	return slots
 }
 
+// PlainText returns the loaded comments for reelPK as plain text, one
+// "@username: text" line each with replies indented. GIF comments are noted
+// as "[GIF]". Returns "" if no comments for that reel are loaded.
+func (cp *CommentsPanel) PlainText(reelPK string) string {
+	if cp.reelPK != reelPK || len(cp.comments) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	for _, c := range cp.comments {
+		if c.ParentCommentID != "" {
+			b.WriteString("    ")
+		}
+		text := strings.ReplaceAll(c.Text, "\n", " ")
+		if c.GifUrl != "" || c.GifPath != "" {
+			text = strings.TrimSpace("[GIF] " + text)
+		}
+		b.WriteString("@" + c.Username + ": " + text + "\n")
+	}
+	return b.String()
+}
+
 // SetLoading sets the loading state for the comments panel
 func (cp *CommentsPanel) SetLoading(loading bool) {
 	cp.loading = loading
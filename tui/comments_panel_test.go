@@ -0,0 +1,75 @@
+package tui
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/gif"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/njyeung/reels/backend"
+)
+
+// writeGifs writes a small valid GIF and a copy cut off halfway through, like
+// an interrupted download, and returns their paths.
+func writeGifs(t *testing.T) (valid, truncated string) {
+	t.Helper()
+	img := image.NewPaletted(image.Rect(0, 0, 8, 8), color.Palette{color.Black, color.White})
+	var buf bytes.Buffer
+	if err := gif.Encode(&buf, img, nil); err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	valid = filepath.Join(dir, "valid.gif")
+	truncated = filepath.Join(dir, "truncated.gif")
+	if err := os.WriteFile(valid, buf.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(truncated, buf.Bytes()[:buf.Len()/2], 0644); err != nil {
+		t.Fatal(err)
+	}
+	return valid, truncated
+}
+
+func TestBrokenGifFallsBackToText(t *testing.T) {
+	valid, truncated := writeGifs(t)
+	cp := NewCommentsPanel()
+	cp.gifCellHeight = 3
+	cp.Open("reel")
+	cp.comments = []backend.Comment{
+		{PK: "ok", Username: "alice", GifPath: valid},
+		{PK: "broken", Username: "bob", Text: "look", GifPath: truncated},
+		{PK: "bare", Username: "carol", GifPath: truncated},
+	}
+	cp.decodeGifs(30)
+
+	if _, ok := cp.gifAnims["ok"]; !ok {
+		t.Fatal("valid GIF didn't load")
+	}
+	if _, ok := cp.gifAnims["broken"]; ok {
+		t.Fatal("truncated GIF loaded")
+	}
+	if got := cp.commentText(cp.comments[1]); got != "[GIF] look" {
+		t.Errorf("commentText = %q, want %q", got, "[GIF] look")
+	}
+	if got := cp.commentText(cp.comments[2]); got != "[GIF]" {
+		t.Errorf("commentText = %q, want %q", got, "[GIF]")
+	}
+
+	view := cp.View(40, 30, "")
+	if !strings.Contains(view, "[GIF] look") {
+		t.Errorf("view doesn't show the fallback text:\n%s", view)
+	}
+	// username, text, like line: no rows reserved for an animation
+	if got := cp.commentLines(1); got != 3 {
+		t.Errorf("commentLines(broken) = %d, want 3", got)
+	}
+
+	slots := cp.VisibleGifSlots(40, 30, 0, 0)
+	if len(slots) != 1 || slots[0].Anim != cp.gifAnims["ok"] {
+		t.Fatalf("got %d gif slots, want only the valid GIF's", len(slots))
+	}
+}
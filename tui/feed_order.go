@@ -0,0 +1,86 @@
+package tui
+
+import (
+	"math/rand/v2"
+	"slices"
+)
+
+// feedOrderMode is the order key_next/key_previous walk the captured reels in
+type feedOrderMode int
+
+const (
+	orderCaptured feedOrderMode = iota
+	orderReversed
+	orderShuffled
+	orderModeCount
+)
+
+func (mode feedOrderMode) String() string {
+	switch mode {
+	case orderReversed:
+		return "reversed"
+	case orderShuffled:
+		return "shuffled"
+	}
+	return "captured"
+}
+
+// feedOrder maps navigation onto real feed indices (key_feed_order). Only the
+// stepping changes: the backend, SyncTo and the cache still see real indices.
+type feedOrder struct {
+	mode feedOrderMode
+
+	// perm is the shuffled walk over real indices 1..len(perm). Reels
+	// captured after the shuffle are shuffled onto the end by extend.
+	perm []int
+}
+
+// cycle moves to the next mode. Entering shuffled mode draws a new shuffle
+// that starts at current, so every other reel is still ahead.
+func (o *feedOrder) cycle(current, total int) {
+	o.mode = (o.mode + 1) % orderModeCount
+	o.perm = nil
+	if o.mode != orderShuffled {
+		return
+	}
+	o.extend(total)
+	if i := slices.Index(o.perm, current); i > 0 {
+		o.perm[0], o.perm[i] = o.perm[i], o.perm[0]
+	}
+}
+
+// extend shuffles reels captured since the last call onto the end of perm.
+func (o *feedOrder) extend(total int) {
+	if o.mode != orderShuffled || len(o.perm) >= total {
+		return
+	}
+	fresh := make([]int, 0, total-len(o.perm))
+	for i := len(o.perm) + 1; i <= total; i++ {
+		fresh = append(fresh, i)
+	}
+	rand.Shuffle(len(fresh), func(i, j int) { fresh[i], fresh[j] = fresh[j], fresh[i] })
+	o.perm = append(o.perm, fresh...)
+}
+
+// step returns the real index direction places from index in this order.
+// Past either end it returns an index outside 1..total.
+func (o *feedOrder) step(index, direction, total int) int {
+	switch o.mode {
+	case orderReversed:
+		return index - direction
+	case orderShuffled:
+		i := slices.Index(o.perm, index)
+		if i < 0 {
+			return total + 1
+		}
+		i += direction
+		if i < 0 {
+			return 0
+		}
+		if i >= len(o.perm) {
+			return total + 1
+		}
+		return o.perm[i]
+	}
+	return index + direction
+}
@@ -0,0 +1,178 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mattn/go-runewidth"
+	"github.com/njyeung/reels/player"
+)
+
+// Grid cell layout: each reel gets a gridThumbRows tall thumbnail with a
+// one-line label under it and a blank line/column of gap around it.
+const (
+	gridThumbRows = 8
+	gridCellW     = 12
+	gridCellH     = gridThumbRows + 2
+	gridTopRows   = 2 // header + blank line above the first grid row
+)
+
+// GridView is the overview mode (key_grid): a grid of first-frame thumbnails
+// of the captured reels, for picking one to jump to. Thumbnails exist only for
+// reels already in the video cache; the rest just show their label.
+type GridView struct {
+	isOpen bool
+	total  int
+	cursor int // 0-based; the reel index is cursor+1
+	scroll int // first visible grid row
+
+	// cols and rows is the grid size that fits the terminal
+	cols int
+	rows int
+
+	thumbs  map[int]*player.Img // by reel index
+	pending map[int]bool        // thumbnail decodes already started
+}
+
+func NewGridView() *GridView {
+	return &GridView{}
+}
+
+func (gv *GridView) IsOpen() bool {
+	return gv.isOpen
+}
+
+// Open shows total reels with the cursor on the reel at index.
+func (gv *GridView) Open(total, index int) {
+	gv.isOpen = true
+	gv.total = total
+	gv.cursor = max(min(index-1, total-1), 0)
+	gv.scroll = 0
+	gv.thumbs = make(map[int]*player.Img)
+	gv.pending = make(map[int]bool)
+}
+
+func (gv *GridView) Close() {
+	gv.isOpen = false
+	gv.thumbs = nil
+	gv.pending = nil
+}
+
+// Layout fits the grid to a width x height terminal.
+func (gv *GridView) Layout(width, height int) {
+	gv.cols = max((width-1)/gridCellW, 1)
+	gv.rows = max((height-gridTopRows-1)/gridCellH, 1)
+	gv.Move(0, 0)
+}
+
+// Move moves the cursor dx cells across and dy rows down, auto-scrolling to
+// keep it visible.
+func (gv *GridView) Move(dx, dy int) {
+	if gv.total == 0 || gv.cols == 0 {
+		return
+	}
+	gv.cursor = max(min(gv.cursor+dx+dy*gv.cols, gv.total-1), 0)
+
+	row := gv.cursor / gv.cols
+	if row < gv.scroll {
+		gv.scroll = row
+	}
+	if row >= gv.scroll+gv.rows {
+		gv.scroll = row - gv.rows + 1
+	}
+}
+
+// Cursor returns the reel index (1-based) under the cursor.
+func (gv *GridView) Cursor() int {
+	return gv.cursor + 1
+}
+
+// Visible returns the reel indices currently on screen.
+func (gv *GridView) Visible() []int {
+	first := gv.scroll * gv.cols
+	last := min(first+gv.rows*gv.cols, gv.total)
+	indices := make([]int, 0, max(last-first, 0))
+	for i := first; i < last; i++ {
+		indices = append(indices, i+1)
+	}
+	return indices
+}
+
+// NeedsThumb reports whether index has no thumbnail yet, marking it pending
+// so it's only decoded once.
+func (gv *GridView) NeedsThumb(index int) bool {
+	if gv.pending[index] {
+		return false
+	}
+	gv.pending[index] = true
+	return true
+}
+
+// SetThumb stores the decoded thumbnail for index (nil if decoding failed).
+func (gv *GridView) SetThumb(index int, img *player.Img) {
+	if !gv.isOpen || img == nil {
+		return
+	}
+	gv.thumbs[index] = img
+}
+
+// cellPosition returns the 1-indexed terminal cell of the top-left of the
+// grid cell for reel index.
+func (gv *GridView) cellPosition(index int) (row, col int) {
+	i := index - 1
+	row = gridTopRows + (i/gv.cols-gv.scroll)*gridCellH + 1
+	col = (i%gv.cols)*gridCellW + 2
+	return row, col
+}
+
+// Slots returns the thumbnails to draw for the visible reels.
+func (gv *GridView) Slots() []player.ImageSlot {
+	var slots []player.ImageSlot
+	for _, index := range gv.Visible() {
+		img := gv.thumbs[index]
+		if img == nil {
+			continue
+		}
+		row, col := gv.cellPosition(index)
+		slots = append(slots, player.ImageSlot{Img: img, Row: row, Col: col})
+	}
+	return slots
+}
+
+// View renders the header and the label line under each thumbnail. label
+// returns the text for a reel index.
+func (gv *GridView) View(label func(index int) string) string {
+	if !gv.isOpen {
+		return ""
+	}
+
+	var b strings.Builder
+	header := purple400.Bold(true).Underline(true).Render("Reels")
+	b.WriteString(" " + header + gray500.Render(fmt.Sprintf("  %d/%d", gv.Cursor(), gv.total)) + "\n\n")
+
+	if gv.total == 0 {
+		b.WriteString(" " + gray500.Render("no reels captured yet") + "\n")
+		return b.String()
+	}
+
+	visible := gv.Visible()
+	for start := 0; start < len(visible); start += gv.cols {
+		b.WriteString(strings.Repeat("\n", gridThumbRows))
+
+		var line strings.Builder
+		line.WriteString(" ")
+		for _, index := range visible[start:min(start+gv.cols, len(visible))] {
+			text := truncateByWidth(label(index), gridCellW-1)
+			text += strings.Repeat(" ", max(gridCellW-1-runewidth.StringWidth(text), 0))
+			if index == gv.Cursor() {
+				line.WriteString(pink500.Underline(true).Render(text))
+			} else {
+				line.WriteString(gray500.Render(text))
+			}
+			line.WriteString(" ")
+		}
+		b.WriteString(line.String() + "\n\n")
+	}
+
+	return b.String()
+}
@@ -1,6 +1,7 @@
 package tui
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/njyeung/reels/backend"
@@ -44,22 +45,44 @@ func (hp *HelpPanel) buildEntries() {
 	hp.entries = []helpEntry{
 		{displayKeys(config.KeysNext), "next"},
 		{displayKeys(config.KeysPrevious), "prev"},
+		{displayKeys(config.KeysReelNext), "next, comments stay open"},
+		{displayKeys(config.KeysReelPrevious), "prev, comments stay open"},
+		{displayKeys(config.KeysJumpForward), fmt.Sprintf("jump %d ahead", config.JumpSize)},
+		{displayKeys(config.KeysJumpBack), fmt.Sprintf("jump %d back", config.JumpSize)},
 		{displayKeys(config.KeysPause), "pause"},
 		{displayKeys(config.KeysLike), "like"},
 		{displayKeys(config.KeysRepost), "repost"},
 		{displayKeys(config.KeysMute), "mute"},
 		{displayKeys(config.KeysSeekForward), "seek forward"},
 		{displayKeys(config.KeysSeekBackward), "seek backward"},
+		{displayKeys(config.KeysFrameForward), "step one frame (paused)"},
 		{displayKeys(config.KeysCommentsOpen), "open comments"},
 		{displayKeys(config.KeysCommentsClose), "close comments"},
+		{displayKeys(config.KeysLikeComment), "like comment under cursor"},
+		{displayKeys(config.KeysCommentSort), "sort comments: top/newest"},
 		{displayKeys(config.KeysShareOpen), "share via DM"},
 		{displayKeys(config.KeysShareClose), "send & close share"},
 		{displayKeys(config.KeysSelect), "select (share/friends/react/replies)"},
 		{displayKeys(config.KeysCopyLink), "copy link"},
+		{displayKeys(config.KeysOpenBrowser), "open in browser"},
+		{displayKeys(config.KeysCopyComments), "copy loaded comments"},
+		{displayKeys(config.KeysCopyText), "copy caption (or comment)"},
+		{displayKeys(config.KeysRefreshReel), "refresh like/comment counts"},
 		{displayKeys(config.KeysSave), "bookmark"},
+		{displayKeys(config.KeysExport), "save video to disk"},
 		{displayKeys(config.KeysNavbar), "toggle navbar"},
+		{displayKeys(config.KeysNavbarCompact), "compact navbar"},
+		{displayKeys(config.KeysScrollCaption), "scroll caption (navbar hidden)"},
+		{displayKeys(config.KeysFocus), "focus mode (video only)"},
+		{displayKeys(config.KeysGrid), "grid overview of captured reels"},
+		{displayKeys(config.KeysAutoplay), "autoplay (hands-free)"},
+		{displayKeys(config.KeysFilterMusic), "filter: music / original audio"},
+		{displayKeys(config.KeysFeedOrder), "order: captured / reversed / shuffled"},
+		{displayKeys(config.KeysNightMode), "night mode (dim video)"},
 		{displayKeys(config.KeysVolUp), "volume up"},
 		{displayKeys(config.KeysVolDown), "volume down"},
+		{displayKeys(config.KeysBrightUp), "brighten video"},
+		{displayKeys(config.KeysBrightDown), "darken video"},
 		{displayKeys(config.KeysReelSizeInc), "enlarge video"},
 		{displayKeys(config.KeysReelSizeDec), "shrink video"},
 		{displayKeys(config.KeysChatsOpen), "open DM chats"},
@@ -8,6 +8,7 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/mattn/go-runewidth"
+	"github.com/njyeung/reels/backend"
 )
 
 // HUD message types
@@ -18,6 +19,9 @@ type (
 	dmNotifyFadeTickMsg   struct{}
 	chatBannerHoldMsg     struct{ gen int }
 	chatBannerFadeTickMsg struct{}
+	noticeHoldMsg         struct{ gen int }
+	noticeFadeTickMsg     struct{}
+	likeBurstTickMsg      struct{ gen int }
 )
 
 // hudItem identifies which overlay is currently displayed.
@@ -28,7 +32,9 @@ const (
 	hudNone hudItem = iota
 	hudChatBanner
 	hudVolume
+	hudLike
 	hudDMNotify
+	hudNotice
 )
 
 // HUD holds state for heads-up display overlays (volume indicator, notifications).
@@ -48,8 +54,33 @@ type HUD struct {
 	chatBannerGen      int
 	chatBannerTitle    string
 	chatBannerKeys     []string
+
+	// notice (warnings): 0=hidden, 1=visible (holding), 2-7=fading out
+	noticeFadeStep int
+	noticeGen      int
+	noticeText     string
+
+	// like burst: 0=hidden, 1-len(likeBurstFrames)=animation frame
+	likeStep int
+	likeGen  int
+}
+
+// likeBurstFrames is the heart burst played on like: it grows for the first
+// few frames, then holds its widest shape while likeBurstColors fades it out.
+// Each ♥ is drawn as glyph_heart.
+var likeBurstFrames = [...]string{
+	"♥",
+	"·  ♥  ·",
+	"♥   ♥   ♥",
+	"·   ♥   ♥   ♥   ·",
+	"♥   ♥   ♥   ♥   ♥",
+	"♥   ♥   ♥   ♥   ♥",
+	"♥   ♥   ♥   ♥   ♥",
+	"♥   ♥   ♥   ♥   ♥",
 }
 
+var likeBurstColors = [...]string{"#FF4DA6", "#FF4DA6", "#FF5EAE", "#FF6FB7", "#FF80BF", "#B35A86", "#6B3650", "#262626"}
+
 // ShowVolume triggers the volume indicator
 func (h *HUD) ShowVolume() tea.Cmd {
 	if h.active > hudVolume {
@@ -88,6 +119,33 @@ func (h *HUD) ShowChatBanner(title string, keysReactOpen []string) tea.Cmd {
 	return h.chatBannerHoldTick()
 }
 
+// ShowNotice triggers a one-line warning above the video. It takes priority
+// over every other overlay.
+func (h *HUD) ShowNotice(text string) tea.Cmd {
+	h.volumeFadeStep = 0
+	h.dmNotifyFadeStep = 0
+	h.chatBannerFadeStep = 0
+	h.active = hudNotice
+	h.noticeFadeStep = 1
+	h.noticeText = text
+	h.noticeGen++
+	return h.noticeHoldTick()
+}
+
+// ShowLike plays the heart burst. It gives way to DM notifications and
+// notices, but replaces the volume bar and chat banner.
+func (h *HUD) ShowLike() tea.Cmd {
+	if h.active > hudLike {
+		return nil
+	}
+	h.volumeFadeStep = 0
+	h.chatBannerFadeStep = 0
+	h.active = hudLike
+	h.likeStep = 1
+	h.likeGen++
+	return h.likeBurstTick()
+}
+
 // HideChatBanner dismisses the banner immediately. Called on chat-mode
 // exit, where the react hint would be stale.
 func (h *HUD) HideChatBanner() {
@@ -109,6 +167,18 @@ func (m Model) viewHUD(videoWidthChars, topPad int, padding string) string {
 	b.WriteString(strings.Repeat("\n", max(topPad-3, 0)))
 
 	switch m.hud.active {
+	case hudNotice:
+		fadeColor := lipgloss.Color(hudFadeColor(m.hud.noticeFadeStep))
+		style := lipgloss.NewStyle().Foreground(fadeColor)
+		text := m.hud.noticeText
+		maxWidth := videoWidthChars - 1
+		if runewidth.StringWidth(text) > maxWidth {
+			text = truncateByWidth(text, maxWidth-3) + "..."
+		}
+		textWidth := runewidth.StringWidth(text)
+		leftPad := (maxWidth - textWidth) / 2
+		b.WriteString(padding + strings.Repeat(" ", leftPad) + style.Render(text) + "\n\n")
+
 	case hudDMNotify:
 		fadeColor := lipgloss.Color(hudFadeColor(m.hud.dmNotifyFadeStep))
 		style := lipgloss.NewStyle().Foreground(fadeColor)
@@ -121,6 +191,18 @@ func (m Model) viewHUD(videoWidthChars, topPad int, padding string) string {
 		leftPad := (maxWidth - textWidth) / 2
 		b.WriteString(padding + strings.Repeat(" ", leftPad) + style.Render(text) + "\n\n")
 
+	case hudLike:
+		style := lipgloss.NewStyle().Foreground(lipgloss.Color(likeBurstColors[m.hud.likeStep-1]))
+		heart := backend.GetSettings().GlyphHeart
+		text := strings.ReplaceAll(likeBurstFrames[m.hud.likeStep-1], "♥", heart)
+		maxWidth := videoWidthChars - 1
+		if runewidth.StringWidth(text) > maxWidth {
+			text = heart
+		}
+		textWidth := runewidth.StringWidth(text)
+		leftPad := (maxWidth - textWidth) / 2
+		b.WriteString(padding + strings.Repeat(" ", max(leftPad, 0)) + style.Render(text) + "\n\n")
+
 	case hudVolume:
 		vol := m.player.Volume()
 		barWidth := videoWidthChars - 1
@@ -219,6 +301,44 @@ func (m Model) updateHUD(msg tea.Msg) (bool, Model, tea.Cmd) {
 			return true, m, nil
 		}
 		return true, m, m.hud.chatBannerFadeTick()
+
+	case noticeHoldMsg:
+		if msg.gen != m.hud.noticeGen {
+			return true, m, nil
+		}
+		if m.hud.noticeFadeStep == 1 {
+			m.hud.noticeFadeStep = 2
+			return true, m, m.hud.noticeFadeTick()
+		}
+		return true, m, nil
+
+	case noticeFadeTickMsg:
+		if m.hud.noticeFadeStep < 2 {
+			return true, m, nil
+		}
+		m.hud.noticeFadeStep++
+		if m.hud.noticeFadeStep > 7 {
+			m.hud.noticeFadeStep = 0
+			if m.hud.active == hudNotice {
+				m.hud.active = hudNone
+			}
+			return true, m, nil
+		}
+		return true, m, m.hud.noticeFadeTick()
+
+	case likeBurstTickMsg:
+		if msg.gen != m.hud.likeGen || m.hud.likeStep == 0 {
+			return true, m, nil
+		}
+		m.hud.likeStep++
+		if m.hud.likeStep > len(likeBurstFrames) {
+			m.hud.likeStep = 0
+			if m.hud.active == hudLike {
+				m.hud.active = hudNone
+			}
+			return true, m, nil
+		}
+		return true, m, m.hud.likeBurstTick()
 	}
 
 	return false, m, nil
@@ -262,6 +382,26 @@ func (h HUD) chatBannerFadeTick() tea.Cmd {
 	})
 }
 
+func (h HUD) noticeHoldTick() tea.Cmd {
+	gen := h.noticeGen
+	return tea.Tick(6*time.Second, func(t time.Time) tea.Msg {
+		return noticeHoldMsg{gen: gen}
+	})
+}
+
+func (h HUD) noticeFadeTick() tea.Cmd {
+	return tea.Tick(60*time.Millisecond, func(t time.Time) tea.Msg {
+		return noticeFadeTickMsg{}
+	})
+}
+
+func (h HUD) likeBurstTick() tea.Cmd {
+	gen := h.likeGen
+	return tea.Tick(70*time.Millisecond, func(t time.Time) tea.Msg {
+		return likeBurstTickMsg{gen: gen}
+	})
+}
+
 // hudFadeColor returns the hex color for the fade-out animation.
 // Step 1 = full brightness (gray300), steps 2-7 fade to background.
 func hudFadeColor(step int) string {
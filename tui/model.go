@@ -1,7 +1,10 @@
 package tui
 
 import (
+	"errors"
 	"io"
+	"log"
+	"path/filepath"
 	"slices"
 	"time"
 
@@ -14,23 +17,31 @@ import (
 
 // Messages
 type (
-	backendReadyMsg  struct{}
-	backendErrorMsg  struct{ err error }
-	loginRequiredMsg struct{}
-	loginSuccessMsg  struct{}
-	reelLoadedMsg    struct{ info *backend.ReelInfo }
-	reelErrorMsg     struct{ err error }
-	backendEventMsg  backend.Event
-	videoErrorMsg    struct{ err error }
-	videoReadyMsg    struct {
+	backendReadyMsg    struct{}
+	backendErrorMsg    struct{ err error }
+	loginRequiredMsg   struct{}
+	loginSuccessMsg    struct{}
+	sessionLostMsg     struct{}
+	sessionBackMsg     struct{}
+	commentsTimeoutMsg struct {
+		pk  string
+		gen int
+	}
+	reelLoadedMsg   struct{ info *backend.ReelInfo }
+	reelErrorMsg    struct{ err error }
+	backendEventMsg backend.Event
+	videoErrorMsg   struct{ err error }
+	videoReadyMsg   struct {
 		index           int
 		pfp             *player.Img
 		contextFloating []floatingItem // reel-context pfps from the download (repost/like/sent)
 		chatFloating    []floatingItem // chat-mode sender + reactor pfps
+		finished        <-chan bool    // the play's AVPlayer.Finished
 	}
 	selfReactedMsg       struct{ index int }
 	musicTickMsg         struct{}
 	shareResetMsg        struct{}
+	exportResetMsg       struct{}
 	shareSentMsg         struct{}
 	shareClosedMsg       struct{}
 	shareFailedMsg       struct{}
@@ -39,6 +50,37 @@ type (
 	loadingMsgTickMsg    struct{}
 	loadingScrollTickMsg struct{}
 	loadingFadeTickMsg   struct{}
+	autoplayTickMsg      struct{ gen int }
+	resizeSettleMsg      struct{ gen int }
+	cellSizeTickMsg      struct{}
+	unplayableSkipMsg    struct{ index int }
+	playbackCompleteMsg  struct{ index int }
+	prefetchDoneMsg      struct{}
+	gridThumbMsg         struct {
+		index int
+		img   *player.Img
+	}
+	reelRefreshedMsg struct {
+		info *backend.ReelInfo
+		err  error
+	}
+	reelExportedMsg struct {
+		path string
+		err  error
+	}
+	videoUnplayableMsg struct {
+		index int
+		err   error
+	}
+	commentLikedMsg struct {
+		pk    string
+		liked bool
+		err   error
+	}
+	navigateToMsg struct {
+		index    int
+		accepted chan<- bool
+	}
 )
 
 // floatingItem is a pfp that floats in the reel's bottom-right quadrant with a
@@ -93,10 +135,66 @@ type Model struct {
 	videoRow int
 	videoCol int
 
+	// resizeGen tags the pending resize_debounce_ms timer so only the last
+	// of a burst of resizes re-lays out the video
+	resizeGen int
+	// cellW and cellH are the cell size in pixels the layout was last
+	// computed for, so a font or DPI change that keeps cols/rows is caught
+	cellW, cellH int
+
 	showNavbar bool
+	// navbarCompact shrinks the navbar to a one-line legend, leaving the
+	// freed lines to the caption
+	navbarCompact bool
+
+	// focusMode hides all chrome and enlarges the video to fill the
+	// terminal. It's transient: the configured reel size is left untouched.
+	focusMode bool
+
+	// nightMode dims the video to night_brightness
+	nightMode bool
+
+	// autoplay advances to the next reel on its own (key_autoplay).
+	// autoplayGen tags the running tick so a stale one stops after a toggle;
+	// reelStarted is when the current reel began playing
+	autoplay    bool
+	autoplayGen int
+	reelStarted time.Time
+
+	// unplayableSkips counts reels skipped in a row because their video
+	// wouldn't decode, to stop skipping if everything fails
+	unplayableSkips int
+
+	// cacheStrip is whether each reel from cacheStripFrom on is downloaded,
+	// for the dots beside the navbar's help hint. Refreshed when a reel
+	// starts playing and when its prefetch finishes.
+	cacheStrip     []bool
+	cacheStripFrom int
+
+	// musicFilter limits navigation to reels with or without licensed music
+	// (key_filter_music)
+	musicFilter musicFilter
+
+	// order is the key_feed_order walk over the main feed
+	order feedOrder
+
+	// resumePos is the last playback position per reel PK (resume_position)
+	resumePos map[string]float64
+
+	// syncAttempt/syncTotal track a retrying SyncTo (EventSyncProgress)
+	syncAttempt int
+	syncTotal   int
+
+	// captionScrolling lets j/k scroll a long caption while the navbar is
+	// hidden; captionScroll is the first visible caption line
+	captionScrolling bool
+	captionScroll    int
 
 	// Comments panel encapsulates all comments UI state
 	comments *CommentsPanel
+	// commentsGen tags the pending comment-capture timeout so a stale one
+	// (panel reopened or retried since) is ignored
+	commentsGen int
 
 	// Share panel encapsulates the share/DM friend selection UI
 	share *SharePanel
@@ -113,16 +211,30 @@ type Model struct {
 	// collection + reel prefetch has finished (EventDMReelsReady)
 	dmReelsReady bool
 
+	// grid is the thumbnail overview of the captured reels (key_grid)
+	grid *GridView
+
 	flags Config
 
 	loginSuccess bool
 
+	// sessionExpired is set when Instagram logs the user out mid-browse;
+	// the login view then offers key_recheck_login to recover in place
+	sessionExpired bool
+	loginChecking  bool
+
 	musicScrollOffset int
+	// levels is the recent audio level history drawn by audio_meter, oldest
+	// first, sampled on the music tick
+	levels []float64
 
 	// share button switches to a different emoji for 1s when clicked
 	shareConfirmed bool
 	shareSending   bool
 
+	// the bookmark icon shows a check for 1s after key_export saved the reel
+	exportConfirmed bool
+
 	hud HUD
 
 	reelPFP *player.Img
@@ -144,14 +256,23 @@ type Model struct {
 
 type Config struct {
 	HeadedMode bool
-	LoginMode  bool
+	// HeadedSet is true when --headed was passed explicitly; otherwise the
+	// headed setting in reels.conf decides
+	HeadedSet bool
+	LoginMode bool
+	StartReel string // shortcode of a reel to open first, "" for the feed
+	Debug     bool   // log to reels.log and show A/V drift on the status line
 }
 
 // NewModel creates a new TUI model
 func NewModel(userDataDir, logDir, cacheDir, configDir string, output io.Writer, version string, flags Config) Model {
 	backend.LoadSettings(configDir)
-	backend.InitLogger(logDir)
+	backend.InitLogger(logDir, flags.Debug)
 	settings := backend.GetSettings()
+	if !flags.HeadedSet {
+		flags.HeadedMode = settings.Headed
+	}
+	player.SetFallbackCellSize(settings.FallbackCellW, settings.FallbackCellH)
 
 	playerHeight := settings.ReelHeight * settings.RetinaScale
 	playerWidth := settings.ReelWidth * settings.RetinaScale
@@ -164,10 +285,40 @@ func NewModel(userDataDir, logDir, cacheDir, configDir string, output io.Writer,
 	p := player.NewAVPlayer()
 	p.SetSize(playerWidth, playerHeight)
 	p.SetVolume(settings.Volume)
+	if settings.Muted {
+		p.Mute()
+	}
+	if err := p.SetRenderer(settings.Renderer); err != nil {
+		log.Printf("renderer: %v, falling back to auto", err)
+		p.SetRenderer("auto")
+	}
 	p.SetUseShm(shm.ShmSupported())
+	switch settings.SyncUpdate {
+	case "on":
+		p.SetSyncUpdate(true)
+	case "off":
+		p.SetSyncUpdate(false)
+	default:
+		p.SetSyncUpdate(player.SyncUpdateSupported())
+	}
 	p.SetRetinaScale(settings.RetinaScale)
+	p.SetAudioFade(time.Duration(settings.AudioFadeMs) * time.Millisecond)
+	p.SetNormalizeAudio(settings.NormalizeAudio)
+	p.SetFrameBuffer(settings.FrameBuffer)
+	p.SetFitMode(settings.FitMode)
+	p.SetSync(time.Duration(settings.SyncThresholdMs)*time.Millisecond, settings.SyncMode)
+	p.SetHoldFrame(settings.HoldFrame)
+	p.SetLoopCount(settings.LoopCount)
+	p.SetBrightness(settings.Brightness)
+	player.SetAnimatedPFP(settings.AnimatedPfp)
+	player.SetAudioBuffer(time.Duration(settings.AudioBufferMs) * time.Millisecond)
 
 	b := backend.NewChromeBackend(userDataDir, cacheDir, configDir)
+	if flags.StartReel != "" {
+		b.SetStartReel(flags.StartReel)
+	} else if settings.Resume {
+		b.SetStartReel(b.LoadResumeState())
+	}
 
 	return Model{
 		state:         stateLoading,
@@ -178,16 +329,32 @@ func NewModel(userDataDir, logDir, cacheDir, configDir string, output io.Writer,
 		videoWidthPx:  playerWidth,
 		videoHeightPx: playerHeight,
 		comments:      NewCommentsPanel(),
+		resumePos:     make(map[string]float64),
 		share:         NewSharePanel(),
 		help:          NewHelpPanel(),
 		chats:         NewChatsPanel(),
 		react:         NewReactPanel(),
+		grid:          NewGridView(),
 		flags:         flags,
 		showNavbar:    settings.ShowNavbar,
+		navbarCompact: settings.NavbarCompact,
 		version:       version,
 	}
 }
 
+// Backend returns the backend the model drives, for --serve to read from.
+func (m Model) Backend() backend.Backend {
+	return m.backend
+}
+
+// NavigateTo returns a message that moves the player to the reel at index
+// (1-based), for sending into a running program from outside the TUI. It's
+// ignored while a reel is still loading or the feed isn't showing; accepted
+// (buffered) gets whether the move went ahead.
+func NavigateTo(index int, accepted chan<- bool) tea.Msg {
+	return navigateToMsg{index: index, accepted: accepted}
+}
+
 // Init initializes the model
 func (m Model) Init() tea.Cmd {
 	return tea.Batch(
@@ -195,6 +362,7 @@ func (m Model) Init() tea.Cmd {
 		m.startBackend,
 		m.checkVersion,
 		m.fetchLoadingMessages,
+		cellSizeTick(),
 	)
 }
 
@@ -227,6 +395,7 @@ func (m Model) startBackend() tea.Msg {
 func (m Model) listenForEvents() tea.Msg {
 	event, ok := <-m.backend.Events()
 	if !ok {
+		// closed by Stop; there are no more events to wait for
 		return nil
 	}
 	return backendEventMsg(event)
@@ -240,6 +409,54 @@ func (m Model) loadCurrentReel() tea.Msg {
 	return reelLoadedMsg{info}
 }
 
+// checkSession runs after a failed action to tell a logged-out session apart
+// from an ordinary error.
+func (m Model) checkSession() tea.Msg {
+	if needsLogin, err := m.backend.NeedsLogin(); err == nil && needsLogin {
+		return sessionLostMsg{}
+	}
+	return nil
+}
+
+// recheckLogin re-runs NeedsLogin after the session expired, and reloads the
+// feed once the user is logged back in.
+func (m Model) recheckLogin() tea.Msg {
+	needsLogin, err := m.backend.NeedsLogin()
+	if err != nil || needsLogin {
+		return loginRequiredMsg{}
+	}
+	if err := m.backend.ReloadFeed(); err != nil {
+		return backendErrorMsg{err}
+	}
+	return sessionBackMsg{}
+}
+
+// retryFeed loads the feed again after instagram's error page, resuming the
+// session if one was underway (a failed ReloadFeed) or starting it otherwise.
+func (m Model) retryFeed() tea.Msg {
+	if m.sessionExpired {
+		if err := m.backend.ReloadFeed(); err != nil {
+			return backendErrorMsg{err}
+		}
+		return sessionBackMsg{}
+	}
+	if err := m.backend.NavigateToReels(); err != nil {
+		return backendErrorMsg{err}
+	}
+	return backendReadyMsg{}
+}
+
+// expireSession stops playback and switches to the login view, keeping the
+// captured reels so browsing can resume after key_recheck_login.
+func (m *Model) expireSession() {
+	if m.state != stateBrowsing {
+		return
+	}
+	m.player.Stop()
+	m.state = stateLogin
+	m.sessionExpired = true
+}
+
 func (m Model) checkLoginStatus() tea.Msg {
 	// Poll every 2 seconds to check if user has logged in via the browser
 	time.Sleep(2 * time.Second)
@@ -254,6 +471,17 @@ func (m Model) checkLoginStatus() tea.Msg {
 	return loginRequiredMsg{}
 }
 
+// saveResumeState records the reel on screen for the resume setting. DM reels
+// aren't in the feed, so quitting in chat mode leaves the saved reel as it was.
+func (m Model) saveResumeState() {
+	if !backend.GetSettings().Resume || m.currentReel == nil || m.backend.IsChatMode() {
+		return
+	}
+	if err := m.backend.SaveResumeState(m.currentReel.Code); err != nil {
+		log.Printf("save resume state: %v", err)
+	}
+}
+
 // Update handles messages
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
@@ -266,6 +494,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 			m.player.Close()
 			if m.backend != nil {
+				m.saveResumeState()
 				m.backend.Stop()
 			}
 			return m, tea.Quit
@@ -275,33 +504,52 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m.updateBrowsing(msg)
 		}
 
+		if m.state == stateLogin && m.sessionExpired && !m.loginChecking &&
+			slices.Contains(backend.GetSettings().KeysRecheckLogin, key) {
+			m.loginChecking = true
+			return m, m.recheckLogin
+		}
+
+		if m.state == stateError && errors.Is(m.lastErr, backend.ErrErrorPage) &&
+			slices.Contains(backend.GetSettings().KeysRetry, key) {
+			m.state = stateLoading
+			return m, m.retryFeed
+		}
+
 	case tea.MouseMsg: // intercept scrolling and do nothing
 		return m, nil
 
 	case tea.WindowSizeMsg:
+		// Dragging a window sends a burst of these. The text reflows right
+		// away, but the video (and the scaler behind it) is only re-laid out
+		// once the size has settled.
+		first := m.width == 0
 		m.width = msg.Width
 		m.height = msg.Height
+		m.resizeGen++
+		if debounce := backend.GetSettings().ResizeDebounceMs; !first && debounce > 0 {
+			gen := m.resizeGen
+			return m, tea.Tick(time.Duration(debounce)*time.Millisecond, func(time.Time) tea.Msg {
+				return resizeSettleMsg{gen: gen}
+			})
+		}
+		return m, m.relayout()
 
-		// recompute video character dimensions and re-center
-		player.ComputeVideoCharacterDimensions(m.videoWidthPx, m.videoHeightPx)
-		m.player.SetSize(m.videoWidthPx, m.videoHeightPx)
-		m.updateVideoPosition()
-		if m.reelPFP != nil {
-			m.reelPFP.ResizeToCells(2)
+	case resizeSettleMsg:
+		if msg.gen != m.resizeGen {
+			return m, nil
 		}
-		for _, item := range m.floating {
-			if item.pfp != nil {
-				item.pfp.ResizeToCells(3)
+		return m, m.relayout()
+
+	case cellSizeTickMsg:
+		// Some terminals change the pixel size (font size, moving to a
+		// monitor with another DPI) without sending a resize
+		if m.width > 0 {
+			if w, h := cellSize(); w > 0 && (w != m.cellW || h != m.cellH) {
+				return m, tea.Batch(m.relayout(), cellSizeTick())
 			}
 		}
-		if m.share.IsOpen() {
-			m.share.ResizePfps()
-		} else if m.comments.IsOpen() {
-			m.comments.ResizeGifs()
-			m.updateCommentGifs()
-		}
-		m.updateImages()
-		m.player.RedrawVideo()
+		return m, cellSizeTick()
 
 	case spinner.TickMsg:
 		var cmd tea.Cmd
@@ -366,6 +614,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case loginRequiredMsg:
 		m.state = stateLogin
+		m.loginChecking = false
 		if m.flags.LoginMode {
 			// In login mode, poll for login completion
 			return m, m.checkLoginStatus
@@ -378,6 +627,28 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.loginSuccess = true
 		return m, nil
 
+	case commentsTimeoutMsg:
+		if msg.gen != m.commentsGen || m.comments.Loaded() {
+			return m, nil
+		}
+		m.comments.SetLoading(false)
+		if m.comments.IsOpen() && m.currentReel != nil && m.currentReel.PK == msg.pk {
+			m.comments.SetFailed(true)
+		}
+		return m, nil
+
+	case sessionLostMsg:
+		m.expireSession()
+		return m, nil
+
+	case sessionBackMsg:
+		m.state = stateBrowsing
+		m.sessionExpired = false
+		m.loginChecking = false
+		m.status = statusLoading
+		m.comments.Clear()
+		return m, m.loadCurrentReel
+
 	case backendErrorMsg:
 		m.lastErr = msg.err
 		m.state = stateError
@@ -405,6 +676,24 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if msg.Count > 0 {
 				return m, tea.Batch(m.hud.ShowDMNotify(msg.Count), m.listenForEvents)
 			}
+		case backend.EventConfigNotSaved:
+			return m, tea.Batch(m.hud.ShowNotice("config dir not writable: settings won't be saved"), m.listenForEvents)
+		case backend.EventError:
+			// the backend can't carry on (e.g. chrome died): nothing but
+			// quitting will work from here
+			m.player.Stop()
+			m.lastErr = msg.Err
+			m.state = stateError
+			return m, nil
+		case backend.EventResponsesLost:
+			return m, tea.Batch(m.hud.ShowNotice("couldn't read some responses: some reels may be missing"), m.listenForEvents)
+		case backend.EventStartReelMissed:
+			return m, tea.Batch(m.hud.ShowNotice("reel not found: starting from the feed"), m.listenForEvents)
+		case backend.EventLoginRequired:
+			m.expireSession()
+		case backend.EventSyncProgress:
+			m.syncAttempt = msg.Count
+			m.syncTotal = msg.Total
 		case backend.EventChatModeExited:
 			m.player.Stop()
 			m.status = statusLoading
@@ -418,16 +707,28 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.currentReel = msg.info
 		m.status = statusNone
 		m.musicScrollOffset = 0
+		m.captionScroll = 0
 		return m, m.startPlayback(msg.info.Index)
 
 	case musicTickMsg:
 		if m.currentReel != nil && m.currentReel.Music != nil {
 			m.musicScrollOffset++
 		}
+		if backend.GetSettings().AudioMeter {
+			if level, ok := m.player.AudioPeak(); ok {
+				m.levels = append(m.levels, level)
+				if len(m.levels) > meterWidth {
+					m.levels = m.levels[len(m.levels)-meterWidth:]
+				}
+			} else {
+				m.levels = nil // no audio stream: no meter
+			}
+		}
 		return m, m.musicTick()
 
 	case volumeHoldMsg, volumeFadeTickMsg, dmNotifyHoldMsg, dmNotifyFadeTickMsg,
-		chatBannerHoldMsg, chatBannerFadeTickMsg:
+		chatBannerHoldMsg, chatBannerFadeTickMsg, noticeHoldMsg, noticeFadeTickMsg,
+		likeBurstTickMsg:
 		if handled, updated, cmd := m.updateHUD(msg); handled {
 			return updated, cmd
 		}
@@ -436,6 +737,18 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.shareConfirmed = false
 		return m, nil
 
+	case exportResetMsg:
+		m.exportConfirmed = false
+		return m, nil
+
+	case reelExportedMsg:
+		if msg.err != nil {
+			log.Printf("export: %v", msg.err)
+			return m, m.hud.ShowNotice("export failed: " + msg.err.Error())
+		}
+		m.exportConfirmed = true
+		return m, tea.Batch(m.queueExportReset(), m.hud.ShowNotice("saved "+filepath.Base(msg.path)))
+
 	case shareFailedMsg:
 		m.shareSending = false
 		return m, nil
@@ -459,18 +772,31 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case reelErrorMsg:
 		m.status = statusReelError
-		return m, nil
+		return m, m.checkSession
 
 	case videoReadyMsg:
 		m.status = statusNone
+		m.unplayableSkips = 0
+		m.reelStarted = time.Now()
 		m.reelPFP = msg.pfp
 		m.reelFloating = msg.contextFloating
 		m.floating = append(slices.Clone(msg.contextFloating), msg.chatFloating...)
 		m.updateVideoPosition()
 		m.updateImages()
-		go m.prefetch(msg.index)
+		m.refreshCacheStrip()
+		return m, tea.Batch(waitForPlayback(msg.index, msg.finished), m.prefetch(msg.index))
+
+	case prefetchDoneMsg:
+		m.refreshCacheStrip()
 		return m, nil
 
+	case playbackCompleteMsg:
+		// loop_count plays are done: move on, unless the user already has
+		if m.currentReel == nil || m.currentReel.Index != msg.index || m.status != statusNone {
+			return m, nil
+		}
+		return m, m.navigateToReel(1)
+
 	case selfReactedMsg:
 		if m.currentReel != nil && m.currentReel.Index == msg.index {
 			m.floating = append(slices.Clone(m.reelFloating), m.chatFloating(msg.index)...)
@@ -478,9 +804,91 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 
-	case videoErrorMsg:
+	case autoplayTickMsg:
+		if !m.autoplay || msg.gen != m.autoplayGen {
+			return m, nil
+		}
+		if m.autoplayDue() {
+			return m, tea.Batch(m.navigateToReel(1), m.autoplayTick())
+		}
+		return m, m.autoplayTick()
+
+	case reelRefreshedMsg:
+		if msg.err != nil {
+			return m, m.hud.ShowNotice("couldn't refresh reel")
+		}
+		// Only the engagement fields: the rest of currentReel (comments, etc.)
+		// may be newer than the copy RefreshCurrent returned
+		if m.currentReel != nil && m.currentReel.PK == msg.info.PK {
+			m.currentReel.LikeCount = msg.info.LikeCount
+			m.currentReel.CommentCount = msg.info.CommentCount
+			m.currentReel.RepostCount = msg.info.RepostCount
+			m.currentReel.Liked = msg.info.Liked
+			m.currentReel.Saved = msg.info.Saved
+			return m, m.hud.ShowNotice("refreshed counts")
+		}
+		return m, nil
+
+	case gridThumbMsg:
+		if m.grid.IsOpen() {
+			m.grid.SetThumb(msg.index, msg.img)
+			m.renderGrid()
+		}
+		return m, nil
+
+	case videoUnplayableMsg:
+		if m.currentReel == nil || m.currentReel.Index != msg.index {
+			return m, nil
+		}
 		m.status = statusVideoError
+		log.Printf("reel %d unplayable: %v", msg.index, msg.err)
+		if m.unplayableSkips >= maxUnplayableSkips {
+			return m, m.hud.ShowNotice("several reels in a row won't play: not skipping")
+		}
+		m.unplayableSkips++
+		index := msg.index
+		return m, tea.Batch(
+			m.hud.ShowNotice("skipped unplayable reel"),
+			tea.Tick(unplayableSkipDelay, func(time.Time) tea.Msg {
+				return unplayableSkipMsg{index: index}
+			}),
+		)
+
+	case commentLikedMsg:
+		if msg.err != nil {
+			// undo the optimistic flip
+			m.comments.SetLiked(msg.pk, msg.liked)
+			return m, m.hud.ShowNotice("couldn't like comment")
+		}
 		return m, nil
+
+	case unplayableSkipMsg:
+		// only if the user hasn't moved on in the meantime
+		if m.currentReel == nil || m.currentReel.Index != msg.index || m.status != statusVideoError {
+			return m, nil
+		}
+		return m, m.navigateToReel(1)
+
+	case navigateToMsg:
+		ok := m.state == stateBrowsing && m.currentReel != nil && m.status != statusLoading &&
+			msg.index >= 1 && msg.index <= m.backend.GetTotal()
+		msg.accepted <- ok
+		if !ok {
+			return m, nil
+		}
+		if m.grid.IsOpen() {
+			return m, m.closeGrid(msg.index)
+		}
+		m.rememberPosition()
+		m.player.Stop()
+		return m, m.goToReel(msg.index)
+
+	case videoErrorMsg:
+		m.status = statusVideoError
+		if errors.Is(msg.err, backend.ErrDiskFull) {
+			return m, m.hud.ShowNotice("disk full: clear ~/.cache/reels or free up space")
+		}
+		return m, m.checkSession
 	}
 
 	return m, nil
@@ -52,6 +52,64 @@ func renderWithMentions(text string, base lipgloss.Style) string {
 	return b.String()
 }
 
+// isHashtagChar reports whether r can appear in a #hashtag.
+func isHashtagChar(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_'
+}
+
+// tagGapPunct closes the gap a removed tag leaves before punctuation
+// ("love this #sunset." -> "love this.")
+var tagGapPunct = strings.NewReplacer(" .", ".", " ,", ",", " !", "!", " ?", "?", " :", ":", " ;", ";")
+
+// splitTags pulls the #hashtags and @mentions out of caption for
+// highlight_hashtags. It returns the caption without them, with the spaces
+// they leave behind collapsed and lines left empty dropped, and the tags in
+// order of appearance. A # or @ inside a word (C#, an email address) is not
+// a tag.
+func splitTags(caption string) (text string, tags []string) {
+	var lines []string
+	for _, line := range strings.Split(caption, "\n") {
+		runes := []rune(line)
+		var rest strings.Builder
+		found := false
+		for i := 0; i < len(runes); {
+			r := runes[i]
+			if (r == '#' || r == '@') && (i == 0 || !isHashtagChar(runes[i-1]) && runes[i-1] != '.') {
+				isTagChar := isHashtagChar
+				if r == '@' {
+					isTagChar = isMentionChar
+				}
+				j := i + 1
+				for j < len(runes) && isTagChar(runes[j]) {
+					j++
+				}
+				// a handle can't end in a dot: that's the end of a sentence
+				for r == '@' && j > i+1 && runes[j-1] == '.' {
+					j--
+				}
+				if j > i+1 {
+					tags = append(tags, string(runes[i:j]))
+					found = true
+					i = j
+					continue
+				}
+			}
+			rest.WriteRune(r)
+			i++
+		}
+		if !found {
+			lines = append(lines, line)
+			continue
+		}
+		cleaned := strings.Join(strings.Fields(rest.String()), " ")
+		cleaned = tagGapPunct.Replace(cleaned)
+		if cleaned != "" {
+			lines = append(lines, cleaned)
+		}
+	}
+	return strings.Trim(strings.Join(lines, "\n"), "\n"), tags
+}
+
 // isBreakable returns true if the rune can be broken before or after
 // without needing a space (CJK ideographs, fullwidth chars, emoji, etc).
 func isBreakable(r rune) bool {
@@ -3,6 +3,7 @@ package tui
 import (
 	"fmt"
 	"hash/fnv"
+	"log"
 	"math"
 	"math/rand/v2"
 	"os/exec"
@@ -24,6 +25,15 @@ func (m Model) viewBrowsing() string {
 		return "Loading..."
 	}
 
+	// Focus mode draws nothing but the video itself
+	if m.focusMode {
+		return ""
+	}
+
+	if m.grid.IsOpen() {
+		return m.grid.View(m.gridLabel)
+	}
+
 	// Video dimensions from player package (computed at startup)
 	videoWidthChars := player.VideoWidthChars - 1
 	videoHeightChars := player.VideoHeightChars
@@ -37,72 +47,108 @@ func (m Model) viewBrowsing() string {
 	}
 
 	padding := strings.Repeat(" ", startCol)
-	pfpPadding := strings.Repeat(" ", 5)
+	// Room for the creator pfp beside the username and music lines
+	pfpPadding := ""
+	musicReserve := 0
+	switch m.pfpCorner() {
+	case "bottomleft":
+		pfpPadding = strings.Repeat(" ", pfpCellW+1)
+		musicReserve = pfpCellW + 1
+	case "bottomright":
+		musicReserve = pfpCellW + 1
+	}
 	topPad := m.videoRow - 2
 
-	// total height of screen subtracting the following:
-	//
-	// the top padding (volume status if avaialble),
-	//
-	// likes, comments, share, loading line
-	//
-	// reel video
-	//
-	// username
-	// music
-	maxPanelLines := max(m.height-(topPad+1+(videoHeightChars+1)+2), 1)
+	maxPanelLines := m.maxPanelLines()
 
 	b.WriteString(m.viewHUD(videoWidthChars, topPad, padding))
 
 	// Status line - heart, like count, comment count, play/pause, mute icons
 	// positioned on the right side of video
-	heartIcon := "🤍"
+	settings := backend.GetSettings()
+	heartIcon := settings.GlyphLike
 	likeCount := ""
 	commentCount := ""
-	repostIcon := white.Render("⇄")
+	repostIcon := white.Render(settings.GlyphRepost)
 	repostCount := ""
 	if m.currentReel != nil {
 		if m.currentReel.Liked {
-			heartIcon = "❤️"
+			heartIcon = settings.GlyphLiked
 		}
 		if m.currentReel.Reposted {
-			repostIcon = purple400.Render("⇄")
+			repostIcon = purple400.Render(settings.GlyphRepost)
+		}
+		// show_counts = false keeps the icons but hides engagement numbers
+		if settings.ShowCounts {
+			likeCount = formatLikeCount(m.currentReel.LikeCount)
+			commentCount = formatLikeCount(m.currentReel.CommentCount)
+			repostCount = formatLikeCount(m.currentReel.RepostCount)
 		}
-		likeCount = formatLikeCount(m.currentReel.LikeCount)
-		commentCount = formatLikeCount(m.currentReel.CommentCount)
-		repostCount = formatLikeCount(m.currentReel.RepostCount)
 	}
 
 	playPauseIcon := "  "
 	if m.player.IsPaused() {
 		playPauseIcon = "❚❚"
+	} else if m.autoplay {
+		playPauseIcon = pink400.Render(">>")
 	}
 
 	muteIcon := "  "
 	if m.player.IsMuted() {
 		muteIcon = "M"
 	}
+	// audio_meter follows the mute icon, so a muted reel still shows
+	// whether it has sound
+	if settings.AudioMeter {
+		if meter := m.audioMeter(); meter != "" {
+			muteIcon += " " + meter
+		}
+	}
+	if label := m.musicFilter.label(); label != "" {
+		muteIcon += "   " + pink400.Render(label)
+	}
 
 	// Build status content without padding first
+	// ↗ only means the reel can be sent in-app; the ✔ after a copied link or
+	// a sent share shows either way
 	shareIcon := ""
-	if m.currentReel != nil && m.currentReel.CanViewerReshare {
-		if !m.shareConfirmed {
-			shareIcon = "↗"
-		} else {
-			shareIcon = yellow300.Render("✔")
-		}
+	if m.shareConfirmed {
+		shareIcon = yellow300.Render("✔")
+	} else if m.currentReel != nil && m.currentReel.CanViewerReshare {
+		shareIcon = "↗"
 	}
 
-	saveIcon := "⚐"
+	saveIcon := settings.GlyphUnsaved
 	if m.currentReel != nil && m.currentReel.Saved {
-		saveIcon = "⚑"
+		saveIcon = settings.GlyphSaved
+	}
+	if m.exportConfirmed {
+		saveIcon = yellow300.Render("✔")
 	}
 
-	statusContent := heartIcon + " " + likeCount + "   💬 " + commentCount + "   " + repostIcon + " " + repostCount + "   " + saveIcon + "   " + shareIcon + "   " + playPauseIcon + "   " + muteIcon
+	statusContent := heartIcon + " " + likeCount + "   " + settings.GlyphComment + " " + commentCount + "   " + repostIcon + " " + repostCount + "   " + saveIcon + "   " + shareIcon + "   " + playPauseIcon + "   " + muteIcon
 	contentWidth := lipgloss.Width(statusContent)
 
 	if contentWidth < videoWidthChars-1 {
-		statusContent = statusContent + strings.Repeat(" ", videoWidthChars-1-contentWidth)
+		fill := videoWidthChars - 1 - contentWidth
+		// Show sync progress left of the spinner when a sync is retrying
+		syncProgress := ""
+		if m.backend.IsSyncing() && m.syncAttempt > 0 {
+			syncProgress = fmt.Sprintf("syncing %d/%d ", m.syncAttempt, m.syncTotal)
+			if len(syncProgress) >= fill {
+				syncProgress = ""
+			}
+		} else if m.flags.Debug {
+			drift, dropped := m.player.SyncStats()
+			syncProgress = fmt.Sprintf("%+dms %d dropped ", drift.Milliseconds(), dropped)
+			if len(syncProgress) >= fill {
+				syncProgress = fmt.Sprintf("%+dms ", drift.Milliseconds())
+			}
+			if len(syncProgress) >= fill {
+				syncProgress = ""
+			}
+		}
+		statusContent = statusContent + strings.Repeat(" ", fill-len(syncProgress)) + syncProgress
 		if m.status == statusLoading || m.comments.loading || m.backend.IsSyncing() {
 			runes := []rune(statusContent)
 			statusContent = string(runes[:len(runes)-1]) + m.spinner.View()
@@ -117,7 +163,7 @@ func (m Model) viewBrowsing() string {
 		// Verified badge + username
 		var userLine string
 		if m.currentReel.IsVerified {
-			userLine = pfpPadding + pink400.Bold(true).Render("@"+m.currentReel.Username) + " " + blue500.Render("✓")
+			userLine = pfpPadding + pink400.Bold(true).Render("@"+m.currentReel.Username) + " " + blue500.Render(settings.GlyphVerified)
 		} else {
 			userLine = pfpPadding + pink400.Bold(true).Render("@"+m.currentReel.Username)
 		}
@@ -127,10 +173,10 @@ func (m Model) viewBrowsing() string {
 		if m.currentReel.Music != nil {
 			explicit := ""
 			if m.currentReel.Music.IsExplicit {
-				explicit = " [E]"
+				explicit = " " + settings.GlyphExplicit
 			}
 			musicText := m.currentReel.Music.Title + " - " + m.currentReel.Music.Artist + explicit
-			maxMusicWidth := videoWidthChars - runewidth.StringWidth(pfpPadding)
+			maxMusicWidth := videoWidthChars - musicReserve
 
 			// Marquee scroll if text is too long
 			if runewidth.StringWidth(musicText) > maxMusicWidth {
@@ -163,29 +209,58 @@ func (m Model) viewBrowsing() string {
 		} else if m.react.IsOpen() {
 			b.WriteString(m.react.View(videoWidthChars, maxPanelLines, padding))
 		} else {
-			// Normal caption view
+			// Normal caption view. Lines are styled up front so the tag
+			// lines of highlight_hashtags keep their color when cut below.
 			var captionLines []string
 			maxCaptionLen := videoWidthChars
 
-			if !m.showNavbar {
-				for _, line := range strings.Split(m.currentReel.Caption, "\n") {
-					captionLines = append(captionLines, wrapByWidth(line, maxCaptionLen)...)
+			if !m.showNavbar || m.navbarCompact {
+				lines, tagsFrom := m.wrappedCaption(maxCaptionLen)
+				for i, line := range lines {
+					if i >= tagsFrom {
+						captionLines = append(captionLines, blue400.Render(line))
+					} else {
+						captionLines = append(captionLines, renderWithMentions(line, gray300))
+					}
 				}
 			} else {
-				caption := strings.ReplaceAll(m.currentReel.Caption, "\n", " ")
-				if runewidth.StringWidth(caption) > maxCaptionLen {
-					captionLines = []string{truncateByWidth(caption, maxCaptionLen-3) + "..."}
-				} else {
-					captionLines = []string{caption}
+				text, tags := m.captionParts()
+				oneLine := func(s string) string {
+					s = strings.ReplaceAll(s, "\n", " ")
+					if runewidth.StringWidth(s) > maxCaptionLen {
+						return truncateByWidth(s, maxCaptionLen-3) + "..."
+					}
+					return s
+				}
+				if text != "" || len(tags) == 0 {
+					captionLines = append(captionLines, renderWithMentions(oneLine(text), gray300))
+				}
+				if len(tags) > 0 {
+					captionLines = append(captionLines, blue400.Render(oneLine(strings.Join(tags, " "))))
 				}
 			}
 
-			// Truncate caption to available space
-			if len(captionLines) > maxPanelLines {
-				captionLines = captionLines[:maxPanelLines]
+			// Truncate caption to available space. While scrolling, the
+			// last line is reserved for the scroll hint.
+			visible := maxPanelLines
+			if m.showNavbar && m.navbarCompact {
+				visible = max(maxPanelLines-2, 1) // blank line + legend
+			}
+			if m.captionScrolling {
+				visible = max(maxPanelLines-1, 1)
+				start := min(m.captionScroll, max(len(captionLines)-visible, 0))
+				captionLines = captionLines[start:]
+			}
+			if len(captionLines) > visible {
+				captionLines = captionLines[:visible]
 			}
 			for _, line := range captionLines {
-				b.WriteString(padding + renderWithMentions(line, gray300) + "\n")
+				b.WriteString(padding + line + "\n")
+			}
+			if m.captionScrolling && maxPanelLines > 1 {
+				config := backend.GetSettings()
+				hint := displayKeys(config.KeysNext) + "/" + displayKeys(config.KeysPrevious) + ": scroll  " + displayKeys(config.KeysScrollCaption) + ": done"
+				b.WriteString(padding + gray600.Render(hint) + "\n")
 			}
 
 			// navbar (only when comments not open)
@@ -193,12 +268,21 @@ func (m Model) viewBrowsing() string {
 				b.WriteString("\n")
 
 				config := backend.GetSettings()
-				nav1 := gray600.Render(displayKeys(config.KeysNext) + ": next  " + displayKeys(config.KeysPrevious) + ": prev")
-				nav2 := gray600.Render(displayKeys(config.KeysQuit) + ": quit  " + displayKeys(config.KeysNavbar) + ": hide navbar")
-				nav3 := gray600.Render("?: help")
-				b.WriteString(padding + nav1 + "\n")
-				b.WriteString(padding + nav2 + "\n")
-				b.WriteString(padding + nav3 + "\n")
+				help := gray600.Render(displayKeys(config.KeysHelpOpen) + ": help")
+				if strip := m.cacheStripView(); strip != "" {
+					if gap := videoWidthChars - lipgloss.Width(help) - len(m.cacheStrip); gap > 0 {
+						help += strings.Repeat(" ", gap) + strip
+					}
+				}
+				if m.navbarCompact {
+					b.WriteString(padding + help + "\n")
+				} else {
+					nav1 := gray600.Render(displayKeys(config.KeysNext) + ": next  " + displayKeys(config.KeysPrevious) + ": prev")
+					nav2 := gray600.Render(displayKeys(config.KeysQuit) + ": quit  " + displayKeys(config.KeysNavbar) + ": hide navbar")
+					b.WriteString(padding + nav1 + "\n")
+					b.WriteString(padding + nav2 + "\n")
+					b.WriteString(padding + help + "\n")
+				}
 			}
 		}
 	} else {
@@ -233,6 +317,29 @@ func formatLikeCount(count int) string {
 	return fmt.Sprintf("%d", count)
 }
 
+// formatRelativeTime formats a Unix timestamp as how long ago it was, like
+// Instagram does: "now", "45s", "12m", "3h", "2d", "5w". Returns "" for a
+// zero timestamp.
+func formatRelativeTime(unix int64) string {
+	if unix <= 0 {
+		return ""
+	}
+	ago := time.Since(time.Unix(unix, 0))
+	switch {
+	case ago < time.Second:
+		return "now"
+	case ago < time.Minute:
+		return fmt.Sprintf("%ds", int(ago/time.Second))
+	case ago < time.Hour:
+		return fmt.Sprintf("%dm", int(ago/time.Minute))
+	case ago < 24*time.Hour:
+		return fmt.Sprintf("%dh", int(ago/time.Hour))
+	case ago < 7*24*time.Hour:
+		return fmt.Sprintf("%dd", int(ago/(24*time.Hour)))
+	}
+	return fmt.Sprintf("%dw", int(ago/(7*24*time.Hour)))
+}
+
 // Browsing state update & helpers
 
 func (m Model) updateBrowsing(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
@@ -240,6 +347,24 @@ func (m Model) updateBrowsing(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	config := backend.GetSettings()
 	key := msg.String()
 
+	// Any key other than the toggle itself hands control back, then does
+	// what it normally would
+	if m.autoplay && !slices.Contains(config.KeysAutoplay, key) {
+		m.autoplay = false
+		notice := m.hud.ShowNotice("autoplay off")
+		next, cmd := m.updateBrowsing(msg)
+		return next, tea.Batch(notice, cmd)
+	}
+
+	// Focus mode only passes through playback keys
+	if m.focusMode && !isFocusModeKey(config, key) {
+		return m, nil
+	}
+
+	if m.grid.IsOpen() {
+		return m.updateGrid(config, key)
+	}
+
 	switch {
 	// Chats panel select takes priority over other keys
 	case m.chats.IsOpen() && slices.Contains(config.KeysSelect, key):
@@ -311,9 +436,28 @@ func (m Model) updateBrowsing(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			return m, cmd
 		}
 
+	// Reel next/previous never scroll a panel, so comments can stay open
+	// while moving between reels
+	case slices.Contains(config.KeysReelNext, key):
+		if !m.panelOpen() || m.comments.IsOpen() {
+			return m, m.navigateToReel(1)
+		}
+
+	case slices.Contains(config.KeysReelPrevious, key):
+		if !m.panelOpen() || m.comments.IsOpen() {
+			return m, m.navigateToReel(-1)
+		}
+
+	case slices.Contains(config.KeysJumpForward, key):
+		return m, m.jumpReels(config.JumpSize)
+
+	case slices.Contains(config.KeysJumpBack, key):
+		return m, m.jumpReels(-config.JumpSize)
+
 	case slices.Contains(config.KeysMute, key):
 		if m.currentReel != nil {
 			m.player.Mute()
+			go m.backend.SetMuted(m.player.IsMuted())
 			return m, nil
 		}
 
@@ -330,6 +474,9 @@ func (m Model) updateBrowsing(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			if !m.backend.IsSyncing() {
 				m.currentReel.Liked = !m.currentReel.Liked
 				go m.backend.ToggleLike()
+				if m.currentReel.Liked && backend.GetSettings().LikeAnimation {
+					return m, m.hud.ShowLike()
+				}
 			}
 		}
 
@@ -349,6 +496,29 @@ func (m Model) updateBrowsing(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			}
 		}
 
+	case slices.Contains(config.KeysExport, key):
+		if m.currentReel != nil {
+			return m, m.exportReel(m.currentReel.Index)
+		}
+
+	case m.comments.IsOpen() && slices.Contains(config.KeysLikeComment, key):
+		c, ok := m.comments.CursorComment()
+		if !ok {
+			return m, nil
+		}
+		// flip right away; the backend flips its cached copy the same way
+		m.comments.SetLiked(c.PK, !c.HasLikedComment)
+		b := m.backend
+		return m, func() tea.Msg {
+			liked, err := b.ToggleCommentLike(c.PK)
+			return commentLikedMsg{pk: c.PK, liked: liked, err: err}
+		}
+
+	case m.comments.IsOpen() && slices.Contains(config.KeysCommentSort, key):
+		m.comments.ToggleSort()
+		m.updateCommentGifs()
+		return m, nil
+
 	case m.comments.IsOpen() && slices.Contains(config.KeysCommentsClose, key):
 		if !m.backend.IsSyncing() {
 			m.comments.Close()
@@ -356,18 +526,34 @@ func (m Model) updateBrowsing(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			go m.backend.CloseComments()
 		}
 
+	case m.comments.IsOpen() && m.comments.Failed() && slices.Contains(config.KeysCommentsOpen, key):
+		// retry a timed-out capture: reopen the browser's comments panel
+		if m.currentReel != nil && !m.backend.IsSyncing() {
+			m.comments.SetFailed(false)
+			b := m.backend
+			go func() {
+				b.CloseComments()
+				b.OpenComments()
+			}()
+			return m, m.waitForComments()
+		}
+
 	case !m.comments.IsOpen() && slices.Contains(config.KeysCommentsOpen, key):
 		if !m.backend.IsSyncing() && m.currentReel != nil && !m.currentReel.CommentsDisabled && !m.panelOpen() {
 			m.comments.Open(m.currentReel.PK)
 			m.resizeReel(-(config.ReelSizeStep * config.PanelShrinkSteps))
 
+			var wait tea.Cmd
 			if m.currentReel.Comments != nil {
 				m.comments.SetComments(m.currentReel.PK, m.currentReel.Comments)
 				m.updateCommentGifs()
+			} else {
+				wait = m.waitForComments()
 			}
 
 			go m.backend.OpenComments()
 			m.player.RedrawVideo()
+			return m, wait
 		}
 
 	case m.share.IsOpen() && slices.Contains(config.KeysShareClose, key):
@@ -427,9 +613,58 @@ func (m Model) updateBrowsing(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.player.RedrawVideo()
 		}
 
+	case slices.Contains(config.KeysFocus, key):
+		if m.focusMode || !m.panelOpen() {
+			m.toggleFocusMode()
+		}
+
+	case slices.Contains(config.KeysGrid, key):
+		if !m.panelOpen() && m.currentReel != nil && m.status != statusLoading {
+			return m, m.openGrid()
+		}
+
+	case slices.Contains(config.KeysAutoplay, key):
+		m.autoplay = !m.autoplay
+		if !m.autoplay {
+			return m, m.hud.ShowNotice("autoplay off")
+		}
+		m.autoplayGen++
+		return m, tea.Batch(m.hud.ShowNotice("autoplay on"), m.autoplayTick())
+
+	case slices.Contains(config.KeysFilterMusic, key):
+		m.musicFilter = (m.musicFilter + 1) % musicFilterCount
+		if m.musicFilter == musicFilterOff {
+			return m, m.hud.ShowNotice("showing all reels")
+		}
+		return m, m.hud.ShowNotice("showing only reels with " + m.musicFilter.label())
+
+	case slices.Contains(config.KeysFeedOrder, key):
+		if m.currentReel != nil && !m.backend.IsChatMode() {
+			m.order.cycle(m.currentReel.Index, m.backend.GetTotal())
+			return m, m.hud.ShowNotice("feed order: " + m.order.mode.String())
+		}
+
+	case slices.Contains(config.KeysNightMode, key):
+		m.nightMode = !m.nightMode
+		m.applyBrightness()
+
+	case slices.Contains(config.KeysNavbarCompact, key):
+		m.navbarCompact = m.backend.ToggleNavbarCompact()
+
 	case slices.Contains(config.KeysNavbar, key):
 		showNavbar := m.backend.ToggleNavbar()
 		m.showNavbar = showNavbar
+		m.captionScrolling = false
+		m.captionScroll = 0
+
+	case slices.Contains(config.KeysScrollCaption, key):
+		// Only the expanded (navbar hidden) caption can be scrolled
+		if m.captionScrolling {
+			m.captionScrolling = false
+			m.captionScroll = 0
+		} else if !m.showNavbar && !m.panelOpen() && m.currentReel != nil {
+			m.captionScrolling = true
+		}
 
 	case slices.Contains(config.KeysReelSizeInc, key):
 		m.resizeReel(config.ReelSizeStep)
@@ -453,11 +688,78 @@ func (m Model) updateBrowsing(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		go m.backend.SetVolume(vol)
 		return m, m.hud.ShowVolume()
 
+	case slices.Contains(config.KeysBrightUp, key), slices.Contains(config.KeysBrightDown, key):
+		step := brightnessStep
+		if slices.Contains(config.KeysBrightDown, key) {
+			step = -step
+		}
+		// round so repeated steps land back on 1 exactly
+		brightness := math.Round(min(max(config.Brightness+step, minBrightness), maxBrightness)*10) / 10
+		m.backend.SetBrightness(brightness)
+		m.applyBrightness()
+		return m, m.hud.ShowNotice(fmt.Sprintf("brightness %d%%", int(math.Round(brightness*100))))
+
+	// The public link works for any reel: can_viewer_reshare only governs
+	// sending it in-app (key_share_open)
 	case slices.Contains(config.KeysCopyLink, key):
 		if m.currentReel != nil && m.currentReel.Code != "" {
-			copyToClipboard("https://www.instagram.com/reel/" + m.currentReel.Code)
+			if err := copyToClipboard("https://www.instagram.com/reel/" + m.currentReel.Code); err != nil {
+				log.Printf("copy link: %v", err)
+				return m, m.hud.ShowNotice(copyFailedNotice("link"))
+			}
 			m.shareConfirmed = true
-			return m, m.queueShareReset()
+			return m, tea.Batch(m.queueShareReset(), m.hud.ShowNotice("copied link"))
+		}
+
+	case slices.Contains(config.KeysOpenBrowser, key):
+		if m.currentReel != nil && m.currentReel.Code != "" {
+			if err := openInBrowser("https://www.instagram.com/reel/" + m.currentReel.Code + "/"); err != nil {
+				log.Printf("open in browser: %v", err)
+				return m, m.hud.ShowNotice("couldn't open browser")
+			}
+			return m, m.hud.ShowNotice("opened in browser")
+		}
+
+	case slices.Contains(config.KeysCopyComments, key):
+		if m.currentReel != nil {
+			text := m.comments.PlainText(m.currentReel.PK)
+			if text == "" {
+				return m, m.hud.ShowNotice("no comments loaded yet: open comments first")
+			}
+			if err := copyToClipboard(text); err != nil {
+				log.Printf("copy comments: %v", err)
+				return m, m.hud.ShowNotice(copyFailedNotice("comments"))
+			}
+			return m, m.hud.ShowNotice("copied comments")
+		}
+
+	// Copy text copies the comment under the cursor with comments open,
+	// otherwise the full caption
+	case slices.Contains(config.KeysCopyText, key):
+		if m.comments.IsOpen() {
+			if c, ok := m.comments.CursorComment(); ok && c.Text != "" {
+				if err := copyToClipboard(c.Text); err != nil {
+					log.Printf("copy comment: %v", err)
+					return m, m.hud.ShowNotice(copyFailedNotice("comment"))
+				}
+				return m, m.hud.ShowNotice("copied comment")
+			}
+			return m, nil
+		}
+		if m.currentReel != nil {
+			if m.currentReel.Caption == "" {
+				return m, m.hud.ShowNotice("no caption")
+			}
+			if err := copyToClipboard(m.currentReel.Caption); err != nil {
+				log.Printf("copy caption: %v", err)
+				return m, m.hud.ShowNotice(copyFailedNotice("caption"))
+			}
+			return m, m.hud.ShowNotice("copied caption")
+		}
+
+	case slices.Contains(config.KeysRefreshReel, key):
+		if m.currentReel != nil && !m.backend.IsSyncing() {
+			return m, m.refreshReel
 		}
 
 	case slices.Contains(config.KeysSeekBackward, key):
@@ -465,19 +767,30 @@ func (m Model) updateBrowsing(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 	case slices.Contains(config.KeysSeekForward, key):
 		m.player.Skip(5)
+
+	case slices.Contains(config.KeysFrameForward, key):
+		m.player.StepFrame()
 	}
 
 	return m, nil
 }
 
+// A reel whose video won't decode is skipped after unplayableSkipDelay, at most
+// maxUnplayableSkips times in a row
+const (
+	unplayableSkipDelay = time.Second
+	maxUnplayableSkips  = 3
+)
+
 func (m *Model) startPlayback(index int) tea.Cmd {
+	start := m.resumeFrom(index)
 	return func() tea.Msg {
 		videoPath, pfpPath, floatingFiles, err := m.backend.Download(index)
 		if err != nil {
 			return videoErrorMsg{err}
 		}
 		var pfp *player.Img
-		if pfpPath != "" {
+		if pfpPath != "" && backend.GetSettings().ShowProfilePic {
 			if loaded, err := player.LoadPFP(pfpPath); err == nil {
 				loaded.ResizeToCells(2)
 				pfp = loaded
@@ -501,24 +814,128 @@ func (m *Model) startPlayback(index int) tea.Cmd {
 		// chat mode sender + reactions
 		chat := m.chatFloating(index)
 
-		if err := m.player.Play(videoPath); err != nil {
-			return videoErrorMsg{err}
+		if err := m.player.PlayFrom(videoPath, start); err != nil {
+			return videoUnplayableMsg{index: index, err: err}
 		}
 
-		return videoReadyMsg{index: index, pfp: pfp, contextFloating: floating, chatFloating: chat}
+		return videoReadyMsg{index: index, pfp: pfp, contextFloating: floating, chatFloating: chat, finished: m.player.Finished()}
 	}
 }
 
-func (m Model) prefetch(index int) {
-	toDownload1 := index + 1
-	toDownload2 := index + 2
+// waitForPlayback waits for the play of the reel at index to end and reports
+// it if it finished on its own after loop_count plays. Stopped plays report
+// nothing.
+func waitForPlayback(index int, finished <-chan bool) tea.Cmd {
+	if finished == nil || backend.GetSettings().LoopCount == 0 {
+		return nil
+	}
+	return func() tea.Msg {
+		if !<-finished {
+			return nil
+		}
+		return playbackCompleteMsg{index: index}
+	}
+}
 
-	if toDownload1 <= m.backend.GetTotal() {
-		m.backend.Download(toDownload1)
+// prefetch downloads the two reels after index, reporting prefetchDoneMsg
+// so the cache strip catches up.
+func (m Model) prefetch(index int) tea.Cmd {
+	return func() tea.Msg {
+		toDownload1 := m.stepReel(index, 1)
+		toDownload2 := m.stepReel(toDownload1, 1)
+
+		if toDownload1 >= 1 && toDownload1 <= m.backend.GetTotal() {
+			videoPath, _, _, err := m.backend.Download(toDownload1)
+			// open the next reel's decoder now so switching to it starts faster
+			if err == nil && backend.GetSettings().Prewarm {
+				m.player.Prewarm(videoPath)
+			}
+		}
+		if toDownload2 >= 1 && toDownload2 <= m.backend.GetTotal() {
+			m.backend.Download(toDownload2)
+		}
+		return prefetchDoneMsg{}
+	}
+}
+
+// cacheStripRadius is how many reels either side of the current one the
+// cache strip shows
+const cacheStripRadius = 3
+
+// refreshCacheStrip re-reads which reels near the current one are cached.
+func (m *Model) refreshCacheStrip() {
+	m.cacheStrip = nil
+	if m.currentReel == nil {
+		return
 	}
-	if toDownload2 <= m.backend.GetTotal() {
-		m.backend.Download(toDownload2)
+	from := max(m.currentReel.Index-cacheStripRadius, 1)
+	to := min(m.currentReel.Index+cacheStripRadius, m.backend.GetTotal())
+	for i := from; i <= to; i++ {
+		m.cacheStrip = append(m.cacheStrip, m.backend.IsCached(i))
 	}
+	m.cacheStripFrom = from
+}
+
+// cacheStripView draws the cache strip: a dot per nearby reel, filled if it's
+// downloaded and plays at once, with the current reel in white.
+func (m Model) cacheStripView() string {
+	var b strings.Builder
+	for i, cached := range m.cacheStrip {
+		dot, style := "○", gray600
+		if cached {
+			dot, style = "●", pink400
+		}
+		if m.currentReel != nil && m.cacheStripFrom+i == m.currentReel.Index {
+			style = white
+		}
+		b.WriteString(style.Render(dot))
+	}
+	return b.String()
+}
+
+// autoplayTickInterval is how often autoplay checks whether to move on
+const autoplayTickInterval = 500 * time.Millisecond
+
+func (m Model) autoplayTick() tea.Cmd {
+	gen := m.autoplayGen
+	return tea.Tick(autoplayTickInterval, func(time.Time) tea.Msg {
+		return autoplayTickMsg{gen: gen}
+	})
+}
+
+// autoplayDue reports whether autoplay should move to the next reel: after
+// autoplay_dwell_seconds of playback, or once the reel has played through.
+// Nothing advances while loading, paused, or with a panel or the grid open.
+func (m Model) autoplayDue() bool {
+	if m.currentReel == nil || m.status != statusNone || m.player.IsPaused() ||
+		m.panelOpen() || m.grid.IsOpen() {
+		return false
+	}
+	if dwell := backend.GetSettings().AutoplayDwell; dwell > 0 {
+		return time.Since(m.reelStarted) >= time.Duration(dwell)*time.Second
+	}
+	return m.player.Loops() > 0
+}
+
+// meterWidth is how many level samples the audio meter shows
+const meterWidth = 6
+
+// meterBars are the audio meter's bar heights, quietest first
+var meterBars = []rune("▁▂▃▄▅▆▇█")
+
+// audioMeter renders the recent peak levels as a small bar graph, right-
+// aligned to meterWidth cells. Empty when the reel has no audio.
+func (m Model) audioMeter() string {
+	if len(m.levels) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString(strings.Repeat(" ", meterWidth-len(m.levels)))
+	for _, level := range m.levels {
+		i := min(int(level*float64(len(meterBars))), len(meterBars)-1)
+		b.WriteRune(meterBars[i])
+	}
+	return purple300.Render(b.String())
 }
 
 func (m Model) musicTick() tea.Cmd {
@@ -533,6 +950,21 @@ func (m Model) queueShareReset() tea.Cmd {
 	})
 }
 
+func (m Model) queueExportReset() tea.Cmd {
+	return tea.Tick(1*time.Second, func(t time.Time) tea.Msg {
+		return exportResetMsg{}
+	})
+}
+
+// exportReel saves the reel at index to download_dir off the UI goroutine,
+// waiting for its download if it's still in flight.
+func (m Model) exportReel(index int) tea.Cmd {
+	return func() tea.Msg {
+		path, err := m.backend.Export(index, backend.GetSettings().DownloadDir)
+		return reelExportedMsg{path: path, err: err}
+	}
+}
+
 // reactToCurrent sends the reaction toggle, then reports back so the
 // reactor's own pfp can be added, updated, or removed live.
 func (m Model) reactToCurrent(emoji string, index int) tea.Cmd {
@@ -593,16 +1025,83 @@ func (m *Model) scrollPanel(direction int) bool {
 		}
 		return true
 	}
+	if m.captionScrolling {
+		m.scrollCaption(direction)
+		return true
+	}
 	return false
 }
 
+// scrollCaption moves the expanded caption by direction lines, clamped so the
+// last line stays at the bottom of the caption area.
+func (m *Model) scrollCaption(direction int) {
+	if m.currentReel == nil {
+		return
+	}
+	visible := max(m.maxPanelLines()-1, 1)
+	lines, _ := m.wrappedCaption(player.VideoWidthChars - 1)
+	total := len(lines)
+	m.captionScroll = max(min(m.captionScroll+direction, total-visible), 0)
+}
+
+// wrappedCaption returns the current reel's caption wrapped to width,
+// preserving its line breaks. With highlight_hashtags the tags follow on
+// their own lines, starting at tagsFrom (len(lines) when there are none).
+func (m Model) wrappedCaption(width int) (lines []string, tagsFrom int) {
+	text, tags := m.captionParts()
+	if text != "" || len(tags) == 0 {
+		for _, line := range strings.Split(text, "\n") {
+			lines = append(lines, wrapByWidth(line, width)...)
+		}
+	}
+	tagsFrom = len(lines)
+	if len(tags) > 0 {
+		lines = append(lines, wrapByWidth(strings.Join(tags, " "), width)...)
+	}
+	return lines, tagsFrom
+}
+
+// captionParts returns the current reel's caption, and with
+// highlight_hashtags the hashtags and mentions split out of it.
+func (m Model) captionParts() (text string, tags []string) {
+	if !backend.GetSettings().HighlightHashtags {
+		return m.currentReel.Caption, nil
+	}
+	return splitTags(m.currentReel.Caption)
+}
+
+// maxPanelLines returns how many lines are left below the reel for the
+// caption, navbar, or an open panel.
+func (m Model) maxPanelLines() int {
+	// total height of screen subtracting the following:
+	//
+	// the top padding (volume status if avaialble),
+	//
+	// likes, comments, share, loading line
+	//
+	// reel video
+	//
+	// username
+	// music
+	topPad := m.videoRow - 2
+	return max(m.height-(topPad+1+(player.VideoHeightChars+1)+2), 1)
+}
+
 // navigateToReel moves to a reel at currentIndex+direction if in bounds and not
 // already loading.
 func (m *Model) navigateToReel(direction int) tea.Cmd {
 	if m.currentReel == nil || m.status == statusLoading {
 		return nil
 	}
-	index := m.currentReel.Index + direction
+	if !m.backend.IsChatMode() {
+		m.order.extend(m.backend.GetTotal())
+	}
+	index := m.stepReel(m.currentReel.Index, direction)
+	// explicit and filtered reels are skipped one at a time past a jump
+	skipDir := 1
+	if direction < 0 {
+		skipDir = -1
+	}
 	if m.backend.IsChatMode() && direction > 0 && index > m.backend.GetTotal() {
 		m.player.Stop()
 		m.status = statusLoading
@@ -614,14 +1113,277 @@ func (m *Model) navigateToReel(direction int) tea.Cmd {
 	if index < 1 || index > m.backend.GetTotal() {
 		return nil
 	}
+
+	var notice tea.Cmd
+	index, skipped := m.skipHidden(index, skipDir)
+	if skipped > 0 {
+		notice = m.hud.ShowNotice(fmt.Sprintf("skipped %d explicit", skipped))
+	}
+	if index < 1 || index > m.backend.GetTotal() {
+		if m.musicFilter != musicFilterOff {
+			return tea.Batch(notice, m.hud.ShowNotice("no more reels with "+m.musicFilter.label()))
+		}
+		return notice
+	}
+
+	m.rememberPosition()
 	m.player.Stop()
+	return tea.Batch(m.goToReel(index), notice)
+}
+
+// jumpReels moves jump reels forward (back if negative) like navigateToReel,
+// stopping short at the first or last captured reel.
+func (m *Model) jumpReels(jump int) tea.Cmd {
+	if m.currentReel == nil || m.status == statusLoading || jump == 0 {
+		return nil
+	}
+	total := m.backend.GetTotal()
+	if !m.backend.IsChatMode() {
+		m.order.extend(total)
+	}
+	step := 1
+	if jump < 0 {
+		step = -1
+	}
+	for n := jump; n != 0; n -= step {
+		if index := m.stepReel(m.currentReel.Index, n); index >= 1 && index <= total {
+			return m.navigateToReel(n)
+		}
+	}
+	return nil
+}
+
+// stepReel returns the real index direction places from index, following
+// key_feed_order in the main feed. Chat mode always goes in order.
+func (m *Model) stepReel(index, direction int) int {
+	if m.backend.IsChatMode() {
+		return index + direction
+	}
+	return m.order.step(index, direction, m.backend.GetTotal())
+}
+
+// goToReel syncs the browser to the reel at index and starts playing it.
+// Playback must already be stopped. An open comments panel stays open and
+// switches to the new reel's comments once the browser gets there.
+func (m *Model) goToReel(index int) tea.Cmd {
+	keepComments := m.comments.IsOpen()
 	m.status = statusLoading
 	m.comments.Clear()
+	m.captionScroll = 0
+	m.syncAttempt = 0
 	if info, err := m.backend.GetReel(index); err == nil {
 		m.currentReel = info
 	}
-	go m.backend.SyncTo(index)
-	return m.startPlayback(index)
+	b := m.backend
+	go func() {
+		b.SyncTo(index)
+		if keepComments {
+			b.OpenComments()
+		}
+	}()
+	play := m.startPlayback(index)
+	if !keepComments {
+		return play
+	}
+
+	m.player.ClearGifs()
+	if m.currentReel == nil || m.currentReel.CommentsDisabled {
+		m.closePanelLayout()
+		return play
+	}
+	m.comments.Open(m.currentReel.PK)
+	if m.currentReel.Comments != nil {
+		m.comments.SetComments(m.currentReel.PK, m.currentReel.Comments)
+		m.updateCommentGifs()
+		return play
+	}
+	return tea.Batch(play, m.waitForComments())
+}
+
+// openGrid stops playback and shows the thumbnail overview with the cursor on
+// the current reel.
+func (m *Model) openGrid() tea.Cmd {
+	m.rememberPosition()
+	m.player.Stop()
+	m.grid.Open(m.backend.GetTotal(), m.currentReel.Index)
+	m.grid.Layout(m.width, m.height)
+	m.renderGrid()
+	return m.loadGridThumbs()
+}
+
+// closeGrid leaves the overview and plays the reel at index.
+func (m *Model) closeGrid(index int) tea.Cmd {
+	m.grid.Close()
+	m.player.RenderStill(nil)
+	return m.goToReel(index)
+}
+
+// updateGrid handles keys while the grid overview is open: next/previous
+// move between rows, seek forward/backward between columns.
+func (m Model) updateGrid(config backend.Settings, key string) (tea.Model, tea.Cmd) {
+	switch {
+	case slices.Contains(config.KeysSelect, key):
+		return m, m.closeGrid(m.grid.Cursor())
+	case slices.Contains(config.KeysGrid, key):
+		return m, m.closeGrid(m.currentReel.Index)
+	case slices.Contains(config.KeysNext, key):
+		m.grid.Move(0, 1)
+	case slices.Contains(config.KeysPrevious, key):
+		m.grid.Move(0, -1)
+	case slices.Contains(config.KeysSeekForward, key):
+		m.grid.Move(1, 0)
+	case slices.Contains(config.KeysSeekBackward, key):
+		m.grid.Move(-1, 0)
+	default:
+		return m, nil
+	}
+	m.renderGrid()
+	return m, m.loadGridThumbs()
+}
+
+// renderGrid draws the visible grid thumbnails.
+func (m *Model) renderGrid() {
+	if err := m.player.RenderStill(m.grid.Slots()); err != nil {
+		log.Printf("grid: %v", err)
+	}
+}
+
+// loadGridThumbs decodes thumbnails for the visible reels whose videos are
+// already cached. Uncached reels are left as a label rather than downloaded.
+func (m *Model) loadGridThumbs() tea.Cmd {
+	var cmds []tea.Cmd
+	for _, index := range m.grid.Visible() {
+		path := m.backend.CachedVideo(index)
+		if path == "" || !m.grid.NeedsThumb(index) {
+			continue
+		}
+		cmds = append(cmds, func() tea.Msg {
+			img, err := player.LoadThumbnail(path)
+			if err != nil {
+				log.Printf("grid thumbnail %d: %v", index, err)
+				return gridThumbMsg{index: index}
+			}
+			img.ResizeToCells(gridThumbRows)
+			return gridThumbMsg{index: index, img: img}
+		})
+	}
+	return tea.Batch(cmds...)
+}
+
+// gridLabel is the text under a reel's grid thumbnail.
+func (m Model) gridLabel(index int) string {
+	info, err := m.backend.GetReel(index)
+	if err != nil || info.Username == "" {
+		return fmt.Sprintf("#%d", index)
+	}
+	return fmt.Sprintf("%d @%s", index, info.Username)
+}
+
+// rememberPosition records how far into the current reel playback got, so
+// resume_position can pick up there when the reel is revisited. Reels watched
+// to within a second of the end start over instead.
+func (m *Model) rememberPosition() {
+	if !backend.GetSettings().ResumePosition || m.currentReel == nil {
+		return
+	}
+	pos, dur := m.player.Progress()
+	if pos < 1 || (dur > 0 && pos > dur-1) {
+		delete(m.resumePos, m.currentReel.PK)
+		return
+	}
+	m.resumePos[m.currentReel.PK] = pos
+}
+
+// resumeFrom returns the remembered start position for the reel at index,
+// or 0 to play from the beginning.
+func (m *Model) resumeFrom(index int) float64 {
+	if !backend.GetSettings().ResumePosition {
+		return 0
+	}
+	info, err := m.backend.GetReel(index)
+	if err != nil {
+		return 0
+	}
+	return m.resumePos[info.PK]
+}
+
+// skipHidden steps index in direction past reels that hide_explicit or the
+// music filter hides. Returns the first reel both allow, which may be out of
+// range if none is left, and how many were skipped for explicit music.
+func (m *Model) skipHidden(index, direction int) (int, int) {
+	hideExplicit := backend.GetSettings().HideExplicit
+	skipped := 0
+	for index >= 1 && index <= m.backend.GetTotal() {
+		info, err := m.backend.GetReel(index)
+		if err != nil {
+			break
+		}
+		explicit := hideExplicit && info.Music != nil && info.Music.IsExplicit
+		if !explicit && m.musicFilter.matches(info) {
+			break
+		}
+		if explicit {
+			skipped++
+		}
+		index = m.stepReel(index, direction)
+	}
+	return index, skipped
+}
+
+// refreshReel re-fetches the current reel's counts (key_refresh_reel)
+func (m Model) refreshReel() tea.Msg {
+	info, err := m.backend.RefreshCurrent()
+	return reelRefreshedMsg{info: info, err: err}
+}
+
+// musicFilter is the key_filter_music setting, cycled off -> music -> original
+// audio -> off.
+type musicFilter int
+
+const (
+	musicFilterOff musicFilter = iota
+	musicFilterMusic
+	musicFilterOriginal
+	musicFilterCount
+)
+
+// label is the status line text for the filter, empty when it's off.
+func (f musicFilter) label() string {
+	switch f {
+	case musicFilterMusic:
+		return "music"
+	case musicFilterOriginal:
+		return "original audio"
+	}
+	return ""
+}
+
+// matches reports whether a reel passes the filter. Reels without licensed
+// music are taken to use their original audio.
+func (f musicFilter) matches(info *backend.ReelInfo) bool {
+	switch f {
+	case musicFilterMusic:
+		return info.Music != nil
+	case musicFilterOriginal:
+		return info.Music == nil
+	}
+	return true
+}
+
+// waitForComments starts the comments_timeout_ms timer for the open reel's
+// comment capture. If EventCommentsCaptured hasn't arrived by then, the panel
+// shows a retry message.
+func (m *Model) waitForComments() tea.Cmd {
+	timeout := backend.GetSettings().CommentsTimeoutMs
+	if timeout <= 0 || m.currentReel == nil {
+		return nil
+	}
+	m.commentsGen++
+	m.comments.SetLoading(true)
+	msg := commentsTimeoutMsg{pk: m.currentReel.PK, gen: m.commentsGen}
+	return tea.Tick(time.Duration(timeout)*time.Millisecond, func(time.Time) tea.Msg {
+		return msg
+	})
 }
 
 // closePanelLayout restores the reel size and video position after a panel (comments/share) is closed.
@@ -632,6 +1394,78 @@ func (m *Model) closePanelLayout() {
 	m.player.RedrawVideo()
 }
 
+// toggleFocusMode switches between the full UI and a chrome-free view where
+// the video is scaled up to fill the terminal.
+func (m *Model) toggleFocusMode() {
+	m.focusMode = !m.focusMode
+	if m.focusMode {
+		m.videoWidthPx, m.videoHeightPx = focusSize()
+	} else {
+		s := backend.GetSettings()
+		m.videoWidthPx = s.ReelWidth * s.RetinaScale
+		m.videoHeightPx = s.ReelHeight * s.RetinaScale
+	}
+	player.ComputeVideoCharacterDimensions(m.videoWidthPx, m.videoHeightPx)
+	m.player.SetSize(m.videoWidthPx, m.videoHeightPx)
+	m.updateVideoPosition()
+	m.updateImages()
+	m.player.RedrawVideo()
+}
+
+// focusSize returns the largest 9:16 pixel box that fits in the terminal,
+// leaving one row free above and below.
+func focusSize() (int, int) {
+	cols, rows, termW, termH, err := player.GetTerminalSize()
+	if err != nil || cols == 0 || rows == 0 || termW == 0 || termH == 0 {
+		s := backend.GetSettings()
+		return s.ReelWidth * s.RetinaScale, s.ReelHeight * s.RetinaScale
+	}
+	h := termH - 2*(termH/rows)
+	w := h * 9 / 16
+	if w > termW {
+		w = termW
+		h = w * 16 / 9
+	}
+	return w, h
+}
+
+// isFocusModeKey reports whether key stays active in focus mode: playback,
+// navigation, volume and the focus toggle itself.
+func isFocusModeKey(config backend.Settings, key string) bool {
+	for _, keys := range [][]string{
+		config.KeysFocus, config.KeysNext, config.KeysPrevious, config.KeysPause,
+		config.KeysReelNext, config.KeysReelPrevious, config.KeysJumpForward, config.KeysJumpBack,
+		config.KeysMute, config.KeysLike, config.KeysSeekForward, config.KeysSeekBackward,
+		config.KeysFrameForward, config.KeysAutoplay,
+		config.KeysVolUp, config.KeysVolDown, config.KeysNightMode,
+		config.KeysBrightUp, config.KeysBrightDown,
+	} {
+		if slices.Contains(keys, key) {
+			return true
+		}
+	}
+	return false
+}
+
+// Limits and step for key_brightness_up/down
+const (
+	brightnessStep = 0.1
+	minBrightness  = 0.1
+	maxBrightness  = 2.0
+)
+
+// applyBrightness sets the video brightness from the brightness setting,
+// dimmed further to night_brightness in night mode, and redraws the frame.
+func (m *Model) applyBrightness() {
+	config := backend.GetSettings()
+	brightness := config.Brightness
+	if m.nightMode {
+		brightness *= config.NightBrightness
+	}
+	m.player.SetBrightness(brightness)
+	m.player.RedrawVideo()
+}
+
 // resizeReel adjusts the reel bounding box by delta pixels (width), deriving height from 9:16 ratio.
 func (m *Model) resizeReel(delta int) {
 	settings := backend.GetSettings()
@@ -673,14 +1507,66 @@ func (m Model) updateCommentGifs() {
 	}
 }
 
+// relayout fits the video and everything positioned around it to the current
+// terminal size. The player gets the new size and position in one call so a
+// frame is never drawn with one but not the other.
+func (m *Model) relayout() tea.Cmd {
+	m.cellW, m.cellH = cellSize()
+	if m.focusMode {
+		m.videoWidthPx, m.videoHeightPx = focusSize()
+	}
+	player.ComputeVideoCharacterDimensions(m.videoWidthPx, m.videoHeightPx)
+	m.videoRow, m.videoCol = m.videoBoxPosition()
+	m.player.Resize(m.videoWidthPx, m.videoHeightPx, m.videoRow, m.videoCol)
+
+	if m.reelPFP != nil {
+		m.reelPFP.ResizeToCells(2)
+	}
+	for _, item := range m.floating {
+		if item.pfp != nil {
+			item.pfp.ResizeToCells(3)
+		}
+	}
+	if m.share.IsOpen() {
+		m.share.ResizePfps()
+	} else if m.comments.IsOpen() {
+		m.comments.ResizeGifs()
+		m.updateCommentGifs()
+	}
+	m.updateImages()
+	m.player.RedrawVideo()
+	if m.grid.IsOpen() {
+		m.grid.Layout(m.width, m.height)
+		m.renderGrid()
+		return m.loadGridThumbs()
+	}
+	return nil
+}
+
+// cellSizeCheckInterval is how often the cell size in pixels is re-checked
+// for terminals that don't report a pixel-only change as a resize
+const cellSizeCheckInterval = 2 * time.Second
+
+func cellSizeTick() tea.Cmd {
+	return tea.Tick(cellSizeCheckInterval, func(time.Time) tea.Msg {
+		return cellSizeTickMsg{}
+	})
+}
+
+// cellSize returns the terminal's current cell size in pixels, or 0, 0 if
+// it can't be read.
+func cellSize() (w, h int) {
+	cols, rows, termW, termH, err := player.GetTerminalSize()
+	if err != nil || cols == 0 || rows == 0 {
+		return 0, 0
+	}
+	return termW / cols, termH / rows
+}
+
 // updateVideoPosition computes the centered video position and stores it on the model,
 // then forwards it to the player.
 func (m *Model) updateVideoPosition() {
-	row, col := player.ComputeVideoCenterPosition(m.videoWidthPx, m.videoHeightPx)
-	if m.panelOpen() {
-		row = 5
-	}
-
+	row, col := m.videoBoxPosition()
 	m.videoRow = row
 	m.videoCol = col
 	// Adjust for non-9:16 videos that don't fill the bounding box.
@@ -688,12 +1574,40 @@ func (m *Model) updateVideoPosition() {
 	m.player.SetVideoPosition(row+rowOff, col+colOff)
 }
 
+// videoBoxPosition returns the top-left cell of the video's bounding box:
+// centered, or pinned near the top while a panel is open.
+func (m *Model) videoBoxPosition() (row, col int) {
+	row, col = player.ComputeVideoCenterPosition(m.videoWidthPx, m.videoHeightPx)
+	if m.panelOpen() {
+		row = 5
+	}
+
+	// video_offset_rows/cols nudge the video to make up for tmux status bars
+	// and other chrome, without pushing it off screen
+	settings := backend.GetSettings()
+	if settings.VideoOffsetRows != 0 || settings.VideoOffsetCols != 0 {
+		row += settings.VideoOffsetRows
+		col += settings.VideoOffsetCols
+		if m.height > 0 {
+			row = min(row, m.height-player.VideoHeightChars)
+		}
+		if m.width > 0 {
+			col = min(col, m.width-player.VideoWidthChars+1)
+		}
+		row = max(row, 1)
+		col = max(col, 1)
+	}
+	return row, col
+}
+
 func (m *Model) updateImages() {
 	var slots []player.ImageSlot
 
-	if m.reelPFP != nil {
-		row := max(m.videoRow+player.VideoHeightChars, 1)
-		slots = append(slots, player.ImageSlot{Img: m.reelPFP, Row: row, Col: m.videoCol})
+	if !m.focusMode {
+		if m.reelPFP != nil {
+			row, col := m.profilePicPosition()
+			slots = append(slots, player.ImageSlot{Img: m.reelPFP, Row: row, Col: col})
+		}
 		slots = append(slots, m.floatingPfpSlots()...)
 	}
 
@@ -712,6 +1626,59 @@ func (m *Model) updateImages() {
 	}
 }
 
+// pfpCellH and pfpCellW are a profile picture's size in cells, for the
+// creator pfp and the floating ones alike
+const (
+	pfpCellH = 2
+	pfpCellW = 4
+)
+
+// pfpCorner resolves the pfp_position setting for the current layout. The top
+// corners sit above the status line, so they fall back to the bottom corner on
+// the same side when there aren't enough rows above the video. It's "" with
+// show_profile_pic off, so no room is kept for the pfp.
+func (m *Model) pfpCorner() string {
+	settings := backend.GetSettings()
+	if !settings.ShowProfilePic {
+		return ""
+	}
+	corner := settings.PfpPosition
+	if m.videoRow-1-pfpCellH < 1 {
+		switch corner {
+		case "topleft":
+			corner = "bottomleft"
+		case "topright":
+			corner = "bottomright"
+		}
+	}
+	return corner
+}
+
+// profilePicPosition returns the 1-indexed cell where the creator pfp is drawn,
+// clamped to the terminal.
+func (m *Model) profilePicPosition() (row, col int) {
+	corner := m.pfpCorner()
+
+	// bottom corners use the username/music lines below the video,
+	// top corners the rows just above the status line
+	row = m.videoRow + player.VideoHeightChars
+	if corner == "topleft" || corner == "topright" {
+		row = m.videoRow - 1 - pfpCellH
+	}
+	col = m.videoCol
+	if corner == "topright" || corner == "bottomright" {
+		col = m.videoCol + player.VideoWidthChars - 1 - pfpCellW
+	}
+
+	if m.height > 0 {
+		row = min(row, m.height-pfpCellH+1)
+	}
+	if m.width > 0 {
+		col = min(col, m.width-pfpCellW+1)
+	}
+	return max(row, 1), max(col, 1)
+}
+
 // floatingPfpSlots scatters floating pfps (friend reposts/likes, the DM sender,
 // and chat-mode reactors) across the bottom-right quarter of the reel, each with
 // its badge overlaid. Positions are picked with Mitchell's best-candidate
@@ -723,9 +1690,6 @@ func (m *Model) floatingPfpSlots() []player.ImageSlot {
 		return nil
 	}
 
-	const pfpCellH = 2
-	const pfpCellW = 4
-
 	quadW := player.VideoWidthChars / 4
 	quadH := player.VideoHeightChars / 4
 	quadRow := m.videoRow + player.VideoHeightChars - quadH
@@ -846,17 +1810,60 @@ func (m *Model) chatFloating(index int) []floatingItem {
 	return items
 }
 
-func copyToClipboard(text string) {
+// copyToClipboard puts text on the system clipboard, returning an error if
+// the clipboard tool is missing or fails.
+func copyToClipboard(text string) error {
 	var cmd *exec.Cmd
-	if goruntime.GOOS == "darwin" {
+	switch ClipboardTool() {
+	case "pbcopy":
 		cmd = exec.Command("pbcopy")
+	case "wl-copy":
+		cmd = exec.Command("wl-copy")
+	default:
+		cmd = exec.Command("xclip", "-selection", "clipboard")
+	}
+	cmd.Stdin = strings.NewReader(text)
+	return cmd.Run()
+}
+
+// copyFailedNotice is the HUD notice for a failed copy of what, naming the
+// missing clipboard tool when that's the cause.
+func copyFailedNotice(what string) string {
+	notice := "couldn't copy " + what
+	if ClipboardTool() == "" {
+		notice += ": no clipboard tool found"
+	}
+	return notice
+}
+
+// openInBrowser opens url in the default browser (open on macOS, xdg-open
+// elsewhere) without waiting for it. The browser's output is dropped so it
+// can't draw over the TUI, and on macOS it opens in the background.
+func openInBrowser(url string) error {
+	var cmd *exec.Cmd
+	if goruntime.GOOS == "darwin" {
+		cmd = exec.Command("open", "-g", url)
 	} else {
-		if _, err := exec.LookPath("wl-copy"); err == nil {
-			cmd = exec.Command("wl-copy")
-		} else {
-			cmd = exec.Command("xclip", "-selection", "clipboard")
+		cmd = exec.Command("xdg-open", url)
+	}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	go cmd.Wait()
+	return nil
+}
+
+// ClipboardTool returns the clipboard command copyToClipboard uses (pbcopy,
+// wl-copy or xclip), or "" if none is installed.
+func ClipboardTool() string {
+	tools := []string{"wl-copy", "xclip"}
+	if goruntime.GOOS == "darwin" {
+		tools = []string{"pbcopy"}
+	}
+	for _, tool := range tools {
+		if _, err := exec.LookPath(tool); err == nil {
+			return tool
 		}
 	}
-	cmd.Stdin = strings.NewReader(text)
-	cmd.Run()
+	return ""
 }
@@ -1,11 +1,20 @@
 package tui
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+
+	"github.com/njyeung/reels/backend"
+)
 
 func (m Model) viewError() string {
 	msg := "An error occurred"
 	if m.lastErr != nil {
 		msg += "\n\n\t" + m.lastErr.Error()
 	}
+	if errors.Is(m.lastErr, backend.ErrErrorPage) {
+		retry := displayKeys(backend.GetSettings().KeysRetry)
+		return fmt.Sprintf("\n\n\t%s\n\n\tPress %s to retry, q to quit.\n", msg, retry)
+	}
 	return fmt.Sprintf("\n\n\t%s\n\n\tPress q to quit.\n", msg)
 }
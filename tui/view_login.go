@@ -4,6 +4,7 @@ import (
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/njyeung/reels/backend"
 	"github.com/njyeung/reels/tui/colors"
 )
 
@@ -25,6 +26,15 @@ func (m Model) viewLogin() string {
 			instructions = "Please log in to Instagram in the browser window."
 			statusLine = m.spinner.View() + " Waiting for login..."
 		}
+	} else if m.sessionExpired {
+		// Logged out mid-browse: captured reels are kept, so recover in place
+		recheck := displayKeys(backend.GetSettings().KeysRecheckLogin)
+		title = pink400.Bold(true).Render("Session expired")
+		instructions = "Instagram logged you out.\nLog back in (in the browser window if running with --headed),\nthen press " + recheck + " to check again."
+		if m.loginChecking {
+			statusLine = m.spinner.View() + " Checking login..."
+		}
+		help = gray600.Render(recheck + ": re-check login  q: quit")
 	} else {
 		// Normal mode: tell user to restart with --login
 		title = pink400.Bold(true).Render("Login required")